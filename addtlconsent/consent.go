@@ -0,0 +1,131 @@
+package addtlconsent
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/maps"
+)
+
+const (
+	// V1 strings only contain the list of consented providers, e.g. `1~1.35.41`.
+	V1 = 1
+	// V2 strings also contain the list of disclosed providers, e.g. `2~1.35.41~dv.9.21`.
+	V2 = 2
+)
+
+const disclosedPrefix = "dv."
+
+var (
+	// ErrInvalidVersion is returned when the version prefix is not a supported one.
+	ErrInvalidVersion = errors.New("Invalid additional consent version")
+	// ErrInvalidFormat is returned when the parts of the string don't match its version.
+	ErrInvalidFormat = errors.New("Invalid additional consent format")
+	// ErrInvalidProviderID is returned when any of the provider IDs is not a positive number.
+	ErrInvalidProviderID = errors.New("Invalid additional consent provider ID")
+)
+
+// Consent contains the information of a Google Additional Consent string, sent by
+// publishers using the Additional Consent Mode as `addtl_consent`.
+type Consent struct {
+	// Version of the specification used to encode the string.
+	Version int
+	// Consented is the set of Google Ad Tech Provider IDs the user consented to.
+	Consented map[int]bool
+	// Disclosed is the set of Google Ad Tech Provider IDs disclosed to the user
+	// without being consented. It's always empty in V1 strings.
+	Disclosed map[int]bool
+}
+
+// NewConsent parses an Additional Consent string returning a Consent instance with
+// all the information available. It returns an error if the string is not valid.
+func NewConsent(consent string) (*Consent, error) {
+	parts := strings.Split(consent, "~")
+	version, err := strconv.Atoi(parts[0])
+	if err != nil || (version != V1 && version != V2) {
+		return nil, ErrInvalidVersion
+	}
+	if (version == V1 && len(parts) != 2) || (version == V2 && len(parts) != 3) {
+		return nil, ErrInvalidFormat
+	}
+	c := &Consent{Version: version}
+	if c.Consented, err = parseProviders(parts[1]); err != nil {
+		return nil, err
+	}
+	if version == V2 {
+		if !strings.HasPrefix(parts[2], disclosedPrefix) {
+			return nil, ErrInvalidFormat
+		}
+		if c.Disclosed, err = parseProviders(strings.TrimPrefix(parts[2], disclosedPrefix)); err != nil {
+			return nil, err
+		}
+	} else {
+		c.Disclosed = map[int]bool{}
+	}
+	return c, nil
+}
+
+// HasUserConsented returns true if the user has given consent to the provider ID
+// passed as parameter.
+func (c *Consent) HasUserConsented(providerID int) bool {
+	return c.Consented[providerID]
+}
+
+// IsDisclosed returns true if the provider ID passed as parameter was disclosed to the
+// user, no matter if the user consented to it or not.
+func (c *Consent) IsDisclosed(providerID int) bool {
+	return c.Consented[providerID] || c.Disclosed[providerID]
+}
+
+// ConsentedProviders returns the sorted list of consented provider IDs.
+func (c *Consent) ConsentedProviders() []int {
+	return sortedProviders(c.Consented)
+}
+
+// DisclosedProviders returns the sorted list of provider IDs disclosed without consent.
+func (c *Consent) DisclosedProviders() []int {
+	return sortedProviders(c.Disclosed)
+}
+
+// String encodes the consent back to its Additional Consent string representation.
+func (c *Consent) String() string {
+	encoded := strconv.Itoa(c.Version) + "~" + joinProviders(c.ConsentedProviders())
+	if c.Version == V2 {
+		encoded += "~" + disclosedPrefix + joinProviders(c.DisclosedProviders())
+	}
+	return encoded
+}
+
+// parseProviders parses a list of provider IDs separated by dots into a set.
+func parseProviders(list string) (map[int]bool, error) {
+	providers := map[int]bool{}
+	if list == "" {
+		return providers, nil
+	}
+	for _, value := range strings.Split(list, ".") {
+		providerID, err := strconv.Atoi(value)
+		if err != nil || providerID <= 0 {
+			return nil, ErrInvalidProviderID
+		}
+		providers[providerID] = true
+	}
+	return providers, nil
+}
+
+// sortedProviders returns the provider IDs of a set as a sorted list.
+func sortedProviders(providers map[int]bool) []int {
+	list := maps.Keys(providers)
+	slices.Sort(list)
+	return list
+}
+
+// joinProviders joins a list of provider IDs with dots.
+func joinProviders(providers []int) string {
+	values := make([]string, len(providers))
+	for i, providerID := range providers {
+		values[i] = strconv.Itoa(providerID)
+	}
+	return strings.Join(values, ".")
+}
@@ -0,0 +1,101 @@
+package addtlconsent_test
+
+import (
+	"github.com/hybridtheory/iab-tcf/addtlconsent"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Consent", func() {
+
+	var (
+		consent *addtlconsent.Consent
+		err     error
+	)
+
+	Context("version 1", func() {
+		BeforeEach(func() {
+			consent, err = addtlconsent.NewConsent("1~41.1.35")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("parses the consented providers", func() {
+			Expect(consent.Version).To(Equal(addtlconsent.V1))
+			Expect(consent.ConsentedProviders()).To(Equal([]int{1, 35, 41}))
+			Expect(consent.DisclosedProviders()).To(BeEmpty())
+		})
+
+		DescribeTable("providers consented",
+			func(providerID int, expected bool) {
+				Expect(consent.HasUserConsented(providerID)).To(Equal(expected))
+				Expect(consent.IsDisclosed(providerID)).To(Equal(expected))
+			},
+			Entry("the provider id 1", 1, true),
+			Entry("the provider id 2", 2, false),
+			Entry("the provider id 35", 35, true),
+			Entry("the provider id 41", 41, true),
+		)
+
+		It("encodes it back sorted", func() {
+			Expect(consent.String()).To(Equal("1~1.35.41"))
+		})
+	})
+
+	Context("version 2", func() {
+		BeforeEach(func() {
+			consent, err = addtlconsent.NewConsent("2~1.35.41~dv.9.21")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("parses the consented and disclosed providers", func() {
+			Expect(consent.Version).To(Equal(addtlconsent.V2))
+			Expect(consent.ConsentedProviders()).To(Equal([]int{1, 35, 41}))
+			Expect(consent.DisclosedProviders()).To(Equal([]int{9, 21}))
+		})
+
+		DescribeTable("providers",
+			func(providerID int, consented bool, disclosed bool) {
+				Expect(consent.HasUserConsented(providerID)).To(Equal(consented))
+				Expect(consent.IsDisclosed(providerID)).To(Equal(disclosed))
+			},
+			Entry("the provider id 1", 1, true, true),
+			Entry("the provider id 9", 9, false, true),
+			Entry("the provider id 21", 21, false, true),
+			Entry("the provider id 22", 22, false, false),
+		)
+
+		It("encodes it back", func() {
+			Expect(consent.String()).To(Equal("2~1.35.41~dv.9.21"))
+		})
+	})
+
+	DescribeTable("empty lists",
+		func(value string) {
+			consent, err = addtlconsent.NewConsent(value)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(consent.ConsentedProviders()).To(BeEmpty())
+			Expect(consent.DisclosedProviders()).To(BeEmpty())
+			Expect(consent.String()).To(Equal(value))
+		},
+		Entry("version 1", "1~"),
+		Entry("version 2", "2~~dv."),
+	)
+
+	DescribeTable("invalid strings",
+		func(value string, expected error) {
+			consent, err = addtlconsent.NewConsent(value)
+			Expect(consent).To(BeNil())
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("empty", "", addtlconsent.ErrInvalidVersion),
+		Entry("unknown version", "3~1.35", addtlconsent.ErrInvalidVersion),
+		Entry("non numeric version", "a~1.35", addtlconsent.ErrInvalidVersion),
+		Entry("version 1 without providers", "1", addtlconsent.ErrInvalidFormat),
+		Entry("version 1 with disclosed providers", "1~1.35~dv.9", addtlconsent.ErrInvalidFormat),
+		Entry("version 2 without disclosed providers", "2~1.35", addtlconsent.ErrInvalidFormat),
+		Entry("version 2 without disclosed prefix", "2~1.35~9.21", addtlconsent.ErrInvalidFormat),
+		Entry("non numeric provider", "1~1.a.35", addtlconsent.ErrInvalidProviderID),
+		Entry("empty provider", "1~1..35", addtlconsent.ErrInvalidProviderID),
+		Entry("negative provider", "2~1~dv.-9", addtlconsent.ErrInvalidProviderID),
+	)
+})
@@ -0,0 +1,233 @@
+package iab_tcf
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/LiveRamp/iabconsent"
+)
+
+// ErrUnexpectedEnd is returned when the consent string ends before all its fields are read.
+var ErrUnexpectedEnd = errors.New("Unexpected end of consent string")
+
+// ErrInvalidRange is returned when a range entry ends before its start or past the
+// maximum vendor ID of its section.
+var ErrInvalidRange = errors.New("Invalid vendor range")
+
+// unexpectedEnd wraps the error of an iabconsent reader with ErrUnexpectedEnd, as its
+// reads only fail when the data ends before the bits requested.
+func unexpectedEnd(err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %v", ErrUnexpectedEnd, err)
+}
+
+// maxVendorIDLimit is the highest vendor ID the 16 bits fields of the strings can hold.
+const maxVendorIDLimit = 1<<16 - 1
+
+// bitset is a compact set of IDs starting at 1, where the ID n is the bit n-1.
+type bitset []uint64
+
+// newBitset returns an empty bitset able to hold the IDs up to n without growing.
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+// has returns true if the ID is in the set.
+func (b bitset) has(id int) bool {
+	index := id - 1
+	if index < 0 || index/64 >= len(b) {
+		return false
+	}
+	return b[index/64]&(1<<(index%64)) != 0
+}
+
+// setRange adds the IDs from start to end, both included, growing the set if needed.
+// The words fully covered by the range are filled at once, so its cost depends on the
+// number of words and not on the number of IDs. Invalid ranges are ignored.
+func (b *bitset) setRange(start, end int) {
+	if start < 1 || end < start || end > maxVendorIDLimit {
+		return
+	}
+	if words := (end + 63) / 64; words > len(*b) {
+		*b = append(*b, make(bitset, words-len(*b))...)
+	}
+	first, last := (start-1)/64, (end-1)/64
+	firstMask := ^uint64(0) << ((start - 1) % 64)
+	lastMask := ^uint64(0) >> (63 - (end-1)%64)
+	if first == last {
+		(*b)[first] |= firstMask & lastMask
+		return
+	}
+	(*b)[first] |= firstMask
+	for i := first + 1; i < last; i++ {
+		(*b)[i] = ^uint64(0)
+	}
+	(*b)[last] |= lastMask
+}
+
+// bitReader reads values of any number of bits from a decoded consent string segment.
+// The first error found is kept and every read after it returns zero values.
+type bitReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+// readUint returns the next n bits, with n up to 64, as an unsigned integer.
+func (r *bitReader) readUint(n int) uint64 {
+	if r.err != nil {
+		return 0
+	}
+	if r.pos+n > len(r.data)*8 {
+		r.err = ErrUnexpectedEnd
+		return 0
+	}
+	var value uint64
+	for n > 0 {
+		available := 8 - r.pos%8
+		taken := min(available, n)
+		chunk := uint64(r.data[r.pos/8]>>(available-taken)) & (1<<taken - 1)
+		value = value<<taken | chunk
+		n -= taken
+		r.pos += taken
+	}
+	return value
+}
+
+// readInt returns the next n bits as an integer.
+func (r *bitReader) readInt(n int) int {
+	return int(r.readUint(n))
+}
+
+// readBool returns the next bit as a boolean.
+func (r *bitReader) readBool() bool {
+	return r.readUint(1) == 1
+}
+
+// readTime returns the next 36 bits as a time, stored as deciseconds since the epoch.
+func (r *bitReader) readTime() time.Time {
+	deciseconds := int64(r.readUint(36))
+	return time.Unix(deciseconds/10, (deciseconds%10)*int64(100*time.Millisecond)).UTC()
+}
+
+// readLetters fills the slice with the next letters, stored as 6 bits each with 0 being 'A'.
+func (r *bitReader) readLetters(letters []byte) {
+	for i := range letters {
+		letters[i] = 'A' + byte(r.readUint(6))
+	}
+}
+
+// readFlags returns the next n bits, with n up to 32, as a mask where the ID i is the bit i-1.
+func (r *bitReader) readFlags(n int) uint32 {
+	return bits.Reverse32(uint32(r.readUint(n)) << (32 - n))
+}
+
+// readBitField returns the next n bits as a bitset.
+func (r *bitReader) readBitField(n int) bitset {
+	set := newBitset(n)
+	for i := range set {
+		width := min(64, n-i*64)
+		set[i] = bits.Reverse64(r.readUint(width) << (64 - width))
+	}
+	return set
+}
+
+// readRangeEntry returns the next range entry, keeping ErrInvalidRange as the error if
+// it ends before its start or past maxID.
+func (r *bitReader) readRangeEntry(maxID int) (start, end int) {
+	isRange := r.readBool()
+	start = r.readInt(16)
+	end = start
+	if isRange {
+		end = r.readInt(16)
+	}
+	if r.err == nil {
+		r.err = checkRange(start, end, maxID)
+	}
+	return start, end
+}
+
+// checkRange returns ErrInvalidRange if the range of IDs from start to end ends before
+// its start or past maxID.
+func checkRange(start, end, maxID int) error {
+	if start < 1 || end < start || end > maxID {
+		return fmt.Errorf("%w: %d-%d with maximum vendor ID %d", ErrInvalidRange, start, end, maxID)
+	}
+	return nil
+}
+
+// readRangeEntries returns the next n range entries expanded into a bitset able to hold
+// the IDs up to maxID.
+func (r *bitReader) readRangeEntries(n, maxID int) bitset {
+	set := newBitset(maxID)
+	for i := 0; i < n && r.err == nil; i++ {
+		start, end := r.readRangeEntry(maxID)
+		if r.err == nil {
+			set.setRange(start, end)
+		}
+	}
+	return set
+}
+
+// readVendors returns the next vendor section, with its maximum vendor ID, whether it's
+// range encoded and the vendors as a bitset.
+func (r *bitReader) readVendors() (maxVendorID int, isRange bool, vendors bitset) {
+	maxVendorID = r.readInt(16)
+	isRange = r.readBool()
+	if isRange {
+		vendors = r.readRangeEntries(r.readInt(12), maxVendorID)
+	} else {
+		vendors = r.readBitField(maxVendorID)
+	}
+	return maxVendorID, isRange, vendors
+}
+
+// toMap returns the IDs of the set from 1 to n as a map, as returned by the iabconsent reader.
+func (b bitset) toMap(n int) map[int]bool {
+	values := map[int]bool{}
+	for id := 1; id <= n; id++ {
+		if b.has(id) {
+			values[id] = true
+		}
+	}
+	return values
+}
+
+// ranges returns the IDs of the set grouped in the minimum number of range entries,
+// skipping the words fully set or empty at once.
+func (b bitset) ranges() []*iabconsent.RangeEntry {
+	entries := []*iabconsent.RangeEntry{}
+	for id := b.next(1, true); id > 0; {
+		end := b.next(id, false)
+		entries = append(entries, &iabconsent.RangeEntry{StartVendorID: id, EndVendorID: end - 1})
+		id = b.next(end, true)
+	}
+	return entries
+}
+
+// next returns the first ID from the one received whose presence in the set matches
+// the value received. It returns 0 if no ID is in the set, or the ID following the
+// last word if every ID is.
+func (b bitset) next(id int, value bool) int {
+	index := id - 1
+	for i := index / 64; i < len(b); i++ {
+		word := b[i]
+		if !value {
+			word = ^word
+		}
+		if i == index/64 {
+			word &= ^uint64(0) << (index % 64)
+		}
+		if word != 0 {
+			return i*64 + bits.TrailingZeros64(word) + 1
+		}
+	}
+	if value {
+		return 0
+	}
+	return max(id, len(b)*64+1)
+}
@@ -0,0 +1,118 @@
+package iab_tcf
+
+import (
+	"slices"
+	"strings"
+)
+
+// BitsAppender is implemented by the consents able to append their bitstrings to a buffer
+// without building the strings, such as the ones of this package. It's not part of Consent
+// so the implementations of Consent outside this package don't need to implement it.
+type BitsAppender interface {
+	// AppendConsentPurposeBits appends the GetConsentPurposeBitstring characters to dst
+	// and returns the extended buffer.
+	AppendConsentPurposeBits(dst []byte) []byte
+	// AppendConsentBits appends the GetConsentBitstring characters to dst and returns
+	// the extended buffer, to write them directly into output buffers.
+	AppendConsentBits(dst []byte) []byte
+	// AppendInterestsBits appends the GetInterestsBitstring characters to dst and returns
+	// the extended buffer.
+	AppendInterestsBits(dst []byte) []byte
+}
+
+// AppendConsentPurposeBits appends the GetConsentPurposeBitstring characters of the consent
+// to dst and returns the extended buffer, without building the string if the consent
+// implements BitsAppender.
+func AppendConsentPurposeBits(dst []byte, consent Consent) []byte {
+	if appender, ok := consent.(BitsAppender); ok {
+		return appender.AppendConsentPurposeBits(dst)
+	}
+	return append(dst, consent.GetConsentPurposeBitstring()...)
+}
+
+// AppendConsentBits appends the GetConsentBitstring characters of the consent to dst and
+// returns the extended buffer, without building the string if the consent implements
+// BitsAppender.
+func AppendConsentBits(dst []byte, consent Consent) []byte {
+	if appender, ok := consent.(BitsAppender); ok {
+		return appender.AppendConsentBits(dst)
+	}
+	return append(dst, consent.GetConsentBitstring()...)
+}
+
+// AppendInterestsBits appends the GetInterestsBitstring characters of the consent to dst
+// and returns the extended buffer, without building the string if the consent implements
+// BitsAppender.
+func AppendInterestsBits(dst []byte, consent Consent) []byte {
+	if appender, ok := consent.(BitsAppender); ok {
+		return appender.AppendInterestsBits(dst)
+	}
+	return append(dst, consent.GetInterestsBitstring()...)
+}
+
+// AppendBits appends a '1' or a '0' to dst for each one of the IDs from 1 to n, depending
+// on the value returned by has for that ID, and returns the extended buffer.
+func AppendBits(dst []byte, n int, has func(id int) bool) []byte {
+	dst = slices.Grow(dst, n)
+	for id := 1; id <= n; id++ {
+		dst = append(dst, bitCharacter(has(id)))
+	}
+	return dst
+}
+
+// FormatBits returns a string of 1 & 0 for each one of the IDs from 1 to n, depending on
+// the value returned by has for that ID.
+func FormatBits(n int, has func(id int) bool) string {
+	if n <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.Grow(n)
+	for id := 1; id <= n; id++ {
+		b.WriteByte(bitCharacter(has(id)))
+	}
+	return b.String()
+}
+
+// bitCharacter returns the character used in bitstrings for the value.
+func bitCharacter(value bool) byte {
+	if value {
+		return '1'
+	}
+	return '0'
+}
+
+// appendBits appends a '1' or a '0' to dst for each one of the IDs of the set from 1 to n,
+// reading the set a word at a time.
+func (b bitset) appendBits(dst []byte, n int) []byte {
+	dst = slices.Grow(dst, n)
+	for i := 0; i*64 < n; i++ {
+		var word uint64
+		if i < len(b) {
+			word = b[i]
+		}
+		for bit := 0; bit < 64 && i*64+bit < n; bit++ {
+			dst = append(dst, '0'+byte(word>>bit&1))
+		}
+	}
+	return dst
+}
+
+// format returns a string of 1 & 0 for each one of the IDs of the set from 1 to n.
+func (b bitset) format(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	var builder strings.Builder
+	builder.Grow(n)
+	for i := 0; i*64 < n; i++ {
+		var word uint64
+		if i < len(b) {
+			word = b[i]
+		}
+		for bit := 0; bit < 64 && i*64+bit < n; bit++ {
+			builder.WriteByte('0' + byte(word>>bit&1))
+		}
+	}
+	return builder.String()
+}
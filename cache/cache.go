@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+)
+
+// DefaultSize is the number of consent strings kept by default.
+const DefaultSize = 1024
+
+// Option is the type that allows us to configure the Cache dynamically.
+type Option func(cache *Cache)
+
+// Stats contains the usage statistics of a cache.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	// Size is the number of consent strings cached.
+	Size int
+}
+
+// entry is a consent string cached with the result of decoding it.
+type entry struct {
+	key     string
+	consent iab_tcf.Consent
+	err     error
+}
+
+// Cache is a bounded LRU cache of decoded consent strings keyed by the raw string, safe
+// for concurrent use. The consents returned are shared by every caller of the same
+// string, which is safe as they're immutable.
+type Cache struct {
+	size    int
+	decoder *iab_tcf.Decoder
+
+	mu        sync.Mutex
+	items     map[string]*list.Element
+	order     *list.List
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// WithSize allows to set the maximum number of consent strings kept, DefaultSize if not set.
+func WithSize(size int) Option {
+	return func(cache *Cache) {
+		cache.size = size
+	}
+}
+
+// WithDecoder allows to set the decoder used to decode the consent strings not cached,
+// e.g. to decode them lazily.
+func WithDecoder(decoder *iab_tcf.Decoder) Option {
+	return func(cache *Cache) {
+		cache.decoder = decoder
+	}
+}
+
+// NewCache returns an empty cache with the options received.
+func NewCache(options ...Option) *Cache {
+	cache := &Cache{
+		size:    DefaultSize,
+		decoder: iab_tcf.NewDecoder(),
+		items:   map[string]*list.Element{},
+		order:   list.New(),
+	}
+	for _, option := range options {
+		option(cache)
+	}
+	if cache.size < 1 {
+		cache.size = 1
+	}
+	return cache
+}
+
+// NewConsent returns the consent of the string received, decoding it with iab_tcf.NewConsent
+// only if it's not cached yet. The invalid strings are cached too, returning the same error.
+func (c *Cache) NewConsent(consent string) (iab_tcf.Consent, error) {
+	c.mu.Lock()
+	if element, ok := c.items[consent]; ok {
+		c.order.MoveToFront(element)
+		c.hits++
+		cached := element.Value.(*entry)
+		c.mu.Unlock()
+		return cached.consent, cached.err
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	// Decoded without holding the lock, so the same string may be decoded concurrently
+	// more than once on a miss, keeping the first result.
+	decoded, err := c.decoder.Decode(consent)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.items[consent]; ok {
+		c.order.MoveToFront(element)
+		cached := element.Value.(*entry)
+		return cached.consent, cached.err
+	}
+	c.items[consent] = c.order.PushFront(&entry{key: consent, consent: decoded, err: err})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry).key)
+		c.evictions++
+	}
+	return decoded, err
+}
+
+// Stats returns the usage statistics of the cache.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Size:      c.order.Len(),
+	}
+}
+
+// Purge removes every consent string cached, keeping the statistics.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = map[string]*list.Element{}
+	c.order.Init()
+}
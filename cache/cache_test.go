@@ -0,0 +1,94 @@
+package cache_test
+
+import (
+	"sync"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/cache"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+
+	const (
+		testConsent      = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAAAA"
+		testOtherConsent = "CN-EdYAN-EdYAAKABBENAyCAAHAAAAAAAAhoAFAgAAAAAA"
+		testThirdConsent = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"
+	)
+
+	It("decodes the consent strings", func() {
+		c := cache.NewCache()
+		consent, err := c.NewConsent(testConsent)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.CMPID()).To(Equal(10))
+		Expect(consent.HasUserConsented(5)).To(BeTrue())
+	})
+
+	It("returns the same consent for the same string", func() {
+		c := cache.NewCache()
+		first, _ := c.NewConsent(testConsent)
+		second, _ := c.NewConsent(testConsent)
+		Expect(second).To(BeIdenticalTo(first))
+		Expect(c.Stats()).To(Equal(cache.Stats{Hits: 1, Misses: 1, Size: 1}))
+	})
+
+	It("caches the errors", func() {
+		c := cache.NewCache()
+		_, err := c.NewConsent("invalid")
+		Expect(err).To(HaveOccurred())
+		_, cachedErr := c.NewConsent("invalid")
+		Expect(cachedErr).To(Equal(err))
+		Expect(c.Stats().Hits).To(Equal(uint64(1)))
+	})
+
+	It("evicts the least recently used consent strings", func() {
+		c := cache.NewCache(cache.WithSize(2))
+		first, _ := c.NewConsent(testConsent)
+		c.NewConsent(testOtherConsent)
+		c.NewConsent(testConsent)
+		c.NewConsent(testThirdConsent)
+		Expect(c.Stats()).To(Equal(cache.Stats{Hits: 1, Misses: 3, Evictions: 1, Size: 2}))
+
+		consent, _ := c.NewConsent(testConsent)
+		Expect(consent).To(BeIdenticalTo(first))
+		c.NewConsent(testOtherConsent)
+		Expect(c.Stats().Misses).To(Equal(uint64(4)))
+	})
+
+	It("decodes with the decoder received", func() {
+		c := cache.NewCache(cache.WithDecoder(iab_tcf.NewDecoder(iab_tcf.WithLazyDecoding())))
+		consent, err := c.NewConsent("CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAho")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.(*iab_tcf.ConsentV2).Err()).To(MatchError(iab_tcf.ErrUnexpectedEnd))
+	})
+
+	It("purges the consent strings cached", func() {
+		c := cache.NewCache()
+		c.NewConsent(testConsent)
+		c.Purge()
+		Expect(c.Stats()).To(Equal(cache.Stats{Misses: 1}))
+		c.NewConsent(testConsent)
+		Expect(c.Stats().Misses).To(Equal(uint64(2)))
+	})
+
+	It("is safe for concurrent use", func() {
+		c := cache.NewCache(cache.WithSize(2))
+		values := []string{testConsent, testOtherConsent, testThirdConsent}
+		wg := sync.WaitGroup{}
+		for i := 0; i < 30; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer GinkgoRecover()
+				consent, err := c.NewConsent(values[i%len(values)])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(consent.CMPID()).To(Equal(10))
+			}(i)
+		}
+		wg.Wait()
+		stats := c.Stats()
+		Expect(stats.Hits + stats.Misses).To(Equal(uint64(30)))
+		Expect(stats.Size).To(Equal(2))
+	})
+})
@@ -0,0 +1,403 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/addtlconsent"
+	"github.com/hybridtheory/iab-tcf/export"
+	"github.com/hybridtheory/iab-tcf/gpp"
+	"github.com/hybridtheory/iab-tcf/usprivacy"
+)
+
+// batchSize is the number of lines decoded concurrently before writing their results,
+// so the output keeps the order of the input without holding all of it in memory.
+const batchSize = 4096
+
+// maxLineSize is the maximum size of the lines of the input. Longer lines are reported
+// as errors without decoding them.
+const maxLineSize = 1024 * 1024
+
+// Error classifications of the strings that can't be decoded.
+const (
+	errorEmpty           = "empty"
+	errorMissingColumn   = "missing_column"
+	errorUnknownFormat   = "unknown_format"
+	errorInvalidEncoding = "invalid_encoding"
+	errorTruncated       = "truncated"
+	errorInvalidVersion  = "invalid_version"
+	errorInvalidSection  = "invalid_section"
+	errorInvalidValue    = "invalid_value"
+	errorTooLong         = "too_long"
+	errorInvalid         = "invalid"
+)
+
+// Output formats of the batch command.
+const (
+	outputJSONLines = "jsonl"
+	outputCSV       = "csv"
+	outputParquet   = "parquet"
+)
+
+var (
+	// errMissingColumn is returned when a row doesn't have the column requested.
+	errMissingColumn = errors.New("Missing column")
+	// errLineTooLong is returned when a line is longer than maxLineSize.
+	errLineTooLong = errors.New("Line too long")
+	// errNoTCFConsent is returned when a string is not in a format containing TCF consents.
+	errNoTCFConsent = errors.New("No TCF consent found")
+)
+
+// batchLine is a line of the input to decode.
+type batchLine struct {
+	number int
+	value  string
+	err    error
+}
+
+// batchError is the classification of the error found decoding a line.
+type batchError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// batchResult is the JSON line written for every line of the input.
+type batchResult struct {
+	Line    int         `json:"line"`
+	Input   string      `json:"input"`
+	Decoded record      `json:"decoded,omitempty"`
+	Error   *batchError `json:"error,omitempty"`
+	// row is the flat representation of the TCF consent, for the columnar outputs.
+	row *export.Row
+}
+
+// runBatch decodes the consent strings of every line of the input concurrently and writes
+// a JSON line with the result of each one, in the same order. With the CSV and Parquet
+// outputs it writes a row per TCF consent instead, skipping the other lines.
+func runBatch(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("batch", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	input := flags.String("input", "", "file to read the strings from instead of the standard input")
+	format := flags.String("format", formatAuto, "format of the strings: auto, tcf, gpp, usp or ac")
+	column := flags.Int("column", 0, "1-based column of the strings, to read the input as CSV")
+	delimiter := flags.String("delimiter", ",", "column delimiter of the input, e.g. '\\t' for TSV")
+	header := flags.Bool("header", false, "skip the first line of the input")
+	workers := flags.Int("workers", runtime.NumCPU(), "number of strings decoded concurrently")
+	output := flags.String("output", outputJSONLines, "output format: jsonl, csv or parquet")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *output != outputJSONLines && *output != outputCSV && *output != outputParquet {
+		fmt.Fprintf(stderr, "Unknown output format %q\n", *output)
+		return 2
+	}
+	if *workers < 1 {
+		*workers = 1
+	}
+	comma, err := parseDelimiter(*delimiter)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if *input != "" {
+		file, err := os.Open(*input)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		defer file.Close()
+		stdin = file
+	}
+	lines := make(chan batchLine, batchSize)
+	readErr := make(chan error, 1)
+	// done stops the reader when returning before reading the whole input.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(lines)
+		if *column > 0 {
+			readErr <- readColumn(stdin, *column, comma, *header, lines, done)
+		} else {
+			readErr <- readLines(stdin, *header, lines, done)
+		}
+	}()
+	writer := bufio.NewWriter(stdout)
+	encoder := json.NewEncoder(writer)
+	decodeFunc := func(line batchLine) batchResult { return decodeLine(line, *format) }
+	var rows export.Writer
+	switch *output {
+	case outputCSV:
+		rows = export.NewCSVWriter(writer)
+	case outputParquet:
+		rows = export.NewParquetWriter(writer)
+	}
+	if rows != nil {
+		decodeFunc = func(line batchLine) batchResult { return decodeRow(line, *format) }
+	}
+	skipped := 0
+	batch := make([]batchLine, 0, batchSize)
+	flush := func() error {
+		for _, result := range decodeBatch(batch, *workers, decodeFunc) {
+			var err error
+			switch {
+			case rows == nil:
+				err = encoder.Encode(result)
+			case result.row != nil:
+				err = rows.Write(result.row)
+			default:
+				skipped++
+			}
+			if err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+	for line := range lines {
+		batch = append(batch, line)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				fmt.Fprintln(stderr, err)
+				return 1
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if rows != nil {
+		if err := rows.Close(); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		if skipped > 0 {
+			fmt.Fprintf(stderr, "Skipped %d lines without a valid TCF consent\n", skipped)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if err := <-readErr; err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// parseDelimiter returns the single character delimiter received, allowing `\t` for tabs.
+func parseDelimiter(value string) (rune, error) {
+	if value == `\t` {
+		return '\t', nil
+	}
+	runes := []rune(value)
+	if len(runes) != 1 {
+		return 0, fmt.Errorf("Invalid delimiter %q", value)
+	}
+	return runes[0], nil
+}
+
+// readLines sends every line of the input through the channel until done is closed.
+func readLines(r io.Reader, header bool, lines chan<- batchLine, done <-chan struct{}) error {
+	reader := bufio.NewReaderSize(r, 64*1024)
+	for number := 1; ; number++ {
+		value, err := readLine(reader)
+		if err == io.EOF && value == nil {
+			return nil
+		}
+		if err != nil && err != io.EOF && err != errLineTooLong {
+			return err
+		}
+		if header && number == 1 {
+			continue
+		}
+		line := batchLine{number: number, value: strings.TrimSpace(string(value))}
+		if err == errLineTooLong {
+			line.err = err
+		}
+		if !sendLine(lines, line, done) {
+			return nil
+		}
+	}
+}
+
+// readLine returns the next line of the reader, without reading more than maxLineSize
+// bytes of it into memory. Longer lines are skipped, returning errLineTooLong.
+func readLine(reader *bufio.Reader) ([]byte, error) {
+	var line []byte
+	tooLong := false
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if !tooLong {
+			if len(line)+len(chunk) > maxLineSize {
+				tooLong, line = true, nil
+			} else {
+				line = append(line, chunk...)
+			}
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if tooLong && (err == nil || err == io.EOF) {
+			return []byte{}, errLineTooLong
+		}
+		return line, err
+	}
+}
+
+// readColumn sends the column of every row of the CSV input through the channel until
+// done is closed.
+func readColumn(r io.Reader, column int, comma rune, header bool, lines chan<- batchLine, done <-chan struct{}) error {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+	for number := 1; ; number++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header && number == 1 {
+			continue
+		}
+		line := batchLine{number: number}
+		if column <= len(row) {
+			line.value = strings.TrimSpace(row[column-1])
+		} else {
+			line.err = errMissingColumn
+		}
+		if !sendLine(lines, line, done) {
+			return nil
+		}
+	}
+}
+
+// sendLine sends the line through the channel, returning false if done is closed first.
+func sendLine(lines chan<- batchLine, line batchLine, done <-chan struct{}) bool {
+	select {
+	case lines <- line:
+		return true
+	case <-done:
+		return false
+	}
+}
+
+// decodeBatch decodes the lines received concurrently with decode, returning their results
+// in order.
+func decodeBatch(batch []batchLine, workers int, decode func(line batchLine) batchResult) []batchResult {
+	results := make([]batchResult, len(batch))
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				results[index] = decode(batch[index])
+			}
+		}()
+	}
+	for index := range batch {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// decodeLine returns the result of decoding the line received.
+func decodeLine(line batchLine, format string) batchResult {
+	result := batchResult{Line: line.number, Input: line.value}
+	err := line.err
+	if err == nil {
+		result.Decoded, err = decode(line.value, format)
+	}
+	if err != nil {
+		result.Error = &batchError{Type: classify(line.value, err), Message: err.Error()}
+	}
+	return result
+}
+
+// decodeRow returns the result of decoding the TCF consent of the line received as a row,
+// either directly or from the TCF EU v2 section of a GPP string. Lines that can't be
+// decoded don't have a row.
+func decodeRow(line batchLine, format string) batchResult {
+	result := batchResult{Line: line.number, Input: line.value}
+	if line.err != nil {
+		return result
+	}
+	if consent, err := tcfConsent(line.value, format); err == nil {
+		result.row = export.NewRow(consent)
+	}
+	return result
+}
+
+// tcfConsent returns the TCF consent of the value, in the format received or in the one
+// detected if it's `auto`.
+func tcfConsent(value, format string) (iab_tcf.Consent, error) {
+	if format == formatAuto {
+		format = detectFormat(value)
+	}
+	switch format {
+	case formatTCF:
+		return iab_tcf.NewConsent(value)
+	case formatGPP:
+		consent, err := gpp.NewConsent(value)
+		if err != nil {
+			return nil, err
+		}
+		return consent.TCFEUv2()
+	}
+	return nil, errNoTCFConsent
+}
+
+// classify returns the classification of the error found decoding the value.
+func classify(value string, err error) string {
+	var corruptInputError base64.CorruptInputError
+	switch {
+	case errors.Is(err, errMissingColumn):
+		return errorMissingColumn
+	case errors.Is(err, errLineTooLong):
+		return errorTooLong
+	case value == "":
+		return errorEmpty
+	case errors.Is(err, ErrUnknownFormat):
+		return errorUnknownFormat
+	case errors.As(err, &corruptInputError):
+		return errorInvalidEncoding
+	case errors.Is(err, iab_tcf.ErrUnexpectedEnd):
+		return errorTruncated
+	case errors.Is(err, iab_tcf.ErrInvalidVersion),
+		errors.Is(err, usprivacy.ErrInvalidVersion),
+		errors.Is(err, addtlconsent.ErrInvalidVersion):
+		return errorInvalidVersion
+	case errors.Is(err, gpp.ErrSectionsMismatch),
+		errors.Is(err, gpp.ErrUnsupportedSection),
+		errors.Is(err, gpp.ErrSectionNotFound):
+		return errorInvalidSection
+	case errors.Is(err, usprivacy.ErrInvalidLength),
+		errors.Is(err, usprivacy.ErrInvalidFlag),
+		errors.Is(err, iab_tcf.ErrInvalidRange),
+		errors.Is(err, addtlconsent.ErrInvalidFormat),
+		errors.Is(err, addtlconsent.ErrInvalidProviderID):
+		return errorInvalidValue
+	}
+	return errorInvalid
+}
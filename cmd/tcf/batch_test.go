@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/hybridtheory/iab-tcf/export"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("batch", func() {
+
+	const testConsent = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"
+
+	var stdout, stderr *bytes.Buffer
+
+	execute := func(stdin string, args ...string) []map[string]interface{} {
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+		Expect(run(append([]string{"batch"}, args...), strings.NewReader(stdin), stdout, stderr)).To(Equal(0))
+		results := []map[string]interface{}{}
+		decoder := json.NewDecoder(stdout)
+		for decoder.More() {
+			result := map[string]interface{}{}
+			Expect(decoder.Decode(&result)).To(Succeed())
+			results = append(results, result)
+		}
+		return results
+	}
+
+	errorType := func(result map[string]interface{}) interface{} {
+		return result["error"].(map[string]interface{})["type"]
+	}
+
+	It("decodes every line in order", func() {
+		lines := []string{}
+		for i := 0; i < 100; i++ {
+			lines = append(lines, testConsent, "1YNN")
+		}
+		results := execute(strings.Join(lines, "\n"), "-workers", "8")
+		Expect(results).To(HaveLen(200))
+		for i, result := range results {
+			Expect(result["line"]).To(BeEquivalentTo(i + 1))
+			Expect(result["input"]).To(Equal(lines[i]))
+			Expect(result).To(HaveKey("decoded"))
+			Expect(result).NotTo(HaveKey("error"))
+		}
+		Expect(results[0]["decoded"]).To(HaveKeyWithValue("Format", "TCF v2"))
+		Expect(results[1]["decoded"]).To(HaveKeyWithValue("Format", "US Privacy"))
+	})
+
+	DescribeTable("classifies the errors",
+		func(value, expected string) {
+			results := execute(value + "\n")
+			Expect(results).To(HaveLen(1))
+			Expect(errorType(results[0])).To(Equal(expected))
+		},
+		Entry("of empty lines", " ", errorEmpty),
+		Entry("of invalid base64", "C*", errorInvalidEncoding),
+		Entry("of truncated strings", "CN-EdYAN", errorTruncated),
+		Entry("of truncated TCF v1 strings", "BOlLbqtOlLbqtAVABADECg", errorTruncated),
+		Entry("of truncated GPP headers", "DB", errorTruncated),
+		Entry("of truncated GPP sections", "DBABLA~BV", errorTruncated),
+		Entry("of unknown TCF versions", "AN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAAAA", errorInvalidVersion),
+		Entry("of invalid US Privacy strings", "1YXN", errorInvalidValue),
+		Entry("of invalid vendor ranges", "CAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAFQAYABgAWAAAAA", errorInvalidValue),
+		Entry("of GPP strings with missing sections", "DBACNY~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA", errorInvalidSection),
+	)
+
+	It("reports the lines too long without aborting", func() {
+		results := execute("1YNN\n" + strings.Repeat("C", maxLineSize+1) + "\n1YNN")
+		Expect(results).To(HaveLen(3))
+		Expect(errorType(results[1])).To(Equal(errorTooLong))
+		Expect(results[1]["input"]).To(BeEmpty())
+		Expect(results[2]["line"]).To(BeEquivalentTo(3))
+		Expect(results[2]).NotTo(HaveKey("error"))
+	})
+
+	It("stops reading the input when writing fails", func() {
+		before := runtime.NumGoroutine()
+		input := strings.Repeat("1YNN\n", 4*batchSize)
+		Expect(run([]string{"batch"}, strings.NewReader(input), failingWriter{}, &bytes.Buffer{})).To(Equal(1))
+		Eventually(runtime.NumGoroutine).Should(BeNumerically("<=", before))
+	})
+
+	It("reads a column of a CSV file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "log.csv")
+		Expect(os.WriteFile(path, []byte("id,consent\n1,1YNN\n2\n3,\""+testConsent+"\"\n"), 0o644)).To(Succeed())
+		results := execute("", "-input", path, "-column", "2", "-header")
+		Expect(results).To(HaveLen(3))
+		Expect(results[0]["line"]).To(BeEquivalentTo(2))
+		Expect(results[0]["input"]).To(Equal("1YNN"))
+		Expect(errorType(results[1])).To(Equal(errorMissingColumn))
+		Expect(results[2]["decoded"]).To(HaveKeyWithValue("Format", "TCF v2"))
+	})
+
+	It("reads a column of TSV input", func() {
+		results := execute("a\t1YNN\n", "-column", "2", "-delimiter", `\t`)
+		Expect(results).To(HaveLen(1))
+		Expect(results[0]["input"]).To(Equal("1YNN"))
+	})
+
+	It("forces the format requested", func() {
+		results := execute("1YNN\n", "-format", "gpp")
+		Expect(results[0]).To(HaveKey("error"))
+	})
+
+	It("writes a CSV row per TCF consent", func() {
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+		input := strings.Join([]string{testConsent, "1YNN", "CN-EdYAN", "DBABMA~" + testConsent}, "\n")
+		Expect(run([]string{"batch", "-output", "csv"}, strings.NewReader(input), stdout, stderr)).To(Equal(0))
+		records, err := csv.NewReader(stdout).ReadAll()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(3))
+		Expect(records[0]).To(Equal(export.Columns()))
+		Expect(records[1][slices.Index(records[0], "restriction_start_vendor_ids")]).To(Equal("5"))
+		Expect(records[2]).To(Equal(records[1]))
+		Expect(stderr.String()).To(Equal("Skipped 2 lines without a valid TCF consent\n"))
+	})
+
+	It("writes a Parquet file", func() {
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+		Expect(run([]string{"batch", "-output", "parquet"}, strings.NewReader(testConsent), stdout, stderr)).To(Equal(0))
+		Expect(stdout.String()).To(HavePrefix("PAR1"))
+		Expect(stdout.String()).To(HaveSuffix("PAR1"))
+		Expect(stderr.String()).To(BeEmpty())
+	})
+
+	It("fails with unknown output formats", func() {
+		Expect(run([]string{"batch", "-output", "xml"}, strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{})).To(Equal(2))
+	})
+})
+
+// failingWriter is a writer that always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("Write failed")
+}
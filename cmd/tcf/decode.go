@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/addtlconsent"
+	"github.com/hybridtheory/iab-tcf/gpp"
+	"github.com/hybridtheory/iab-tcf/usprivacy"
+)
+
+const (
+	formatAuto              = "auto"
+	formatTCF               = "tcf"
+	formatGPP               = "gpp"
+	formatUSPrivacy         = "usp"
+	formatAdditionalConsent = "ac"
+)
+
+// unsupportedSection is printed instead of the fields of the GPP sections without a decoder.
+const unsupportedSection = "unsupported"
+
+// gppPrefix is the beginning of every GPP string, encoding the header type and version.
+const gppPrefix = "DB"
+
+// ErrUnknownFormat is returned when the format requested is not a supported one.
+var ErrUnknownFormat = errors.New("Unknown consent string format")
+
+// additionalConsentRegexp matches the version prefix of Google Additional Consent strings.
+var additionalConsentRegexp = regexp.MustCompile(`^\d+~`)
+
+// sectionNames contains the names used by the GPP API for every section ID supported.
+var sectionNames = map[int]string{
+	gpp.TCFEUv2SID:       "tcfeuv2",
+	gpp.TCFCAv1SID:       "tcfcav1",
+	gpp.USPv1SID:         "uspv1",
+	gpp.USNationalSID:    "usnat",
+	gpp.USCaliforniaSID:  "usca",
+	gpp.USVirginiaSID:    "usva",
+	gpp.USColoradoSID:    "usco",
+	gpp.USUtahSID:        "usut",
+	gpp.USConnecticutSID: "usct",
+}
+
+// detectFormat guesses the format of the consent string received.
+func detectFormat(value string) string {
+	switch {
+	case len(value) == usprivacy.Length && value[0] == '1':
+		return formatUSPrivacy
+	case strings.HasPrefix(value, gppPrefix):
+		return formatGPP
+	case additionalConsentRegexp.MatchString(value):
+		return formatAdditionalConsent
+	}
+	return formatTCF
+}
+
+// decode returns the record of every field of the consent string, in the format received
+// or in the one detected if it's `auto`.
+func decode(value, format string) (record, error) {
+	value = strings.TrimSpace(value)
+	if format == formatAuto {
+		format = detectFormat(value)
+	}
+	switch format {
+	case formatTCF:
+		return decodeTCF(value)
+	case formatGPP:
+		return decodeGPP(value)
+	case formatUSPrivacy:
+		consent, err := usprivacy.NewConsent(value)
+		if err != nil {
+			return nil, err
+		}
+		return record{{Name: "Format", Value: "US Privacy"}}.add("Consent", newRecord(consent)), nil
+	case formatAdditionalConsent:
+		consent, err := addtlconsent.NewConsent(value)
+		if err != nil {
+			return nil, err
+		}
+		return record{{Name: "Format", Value: "Additional Consent"}}.add("Consent", newRecord(consent)), nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+// decodeTCF returns the record of a TCF v1 or v2 consent string following the JSON
+// schema of the consents, including the segments following the core one that could be
+// decoded, as the library skips the invalid ones.
+func decodeTCF(value string) (record, error) {
+	consent, err := iab_tcf.NewConsent(value)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := consentRecord(consent)
+	if err != nil {
+		return nil, err
+	}
+	return record{{Name: "Format", Value: fmt.Sprintf("TCF v%d", consent.Version())}}.add("Consent", fields), nil
+}
+
+// consentRecord returns the record of a TCF consent following its JSON schema.
+func consentRecord(consent iab_tcf.Consent) (record, error) {
+	data, err := json.Marshal(consent)
+	if err != nil {
+		return nil, err
+	}
+	return newJSONRecord(data)
+}
+
+// decodeGPP returns the record of a GPP string, including every section it contains. The
+// sections without a decoder are reported as unsupported instead of failing.
+func decodeGPP(value string) (record, error) {
+	consent, err := gpp.NewConsent(value)
+	if err != nil {
+		return nil, err
+	}
+	sections := record{}
+	for _, sectionID := range consent.SectionIDs() {
+		name, ok := sectionNames[sectionID]
+		if !ok {
+			name = fmt.Sprintf("section%d", sectionID)
+		}
+		decoded, err := consent.Decode(sectionID)
+		if errors.Is(err, gpp.ErrUnsupportedSection) {
+			sections = sections.add(name, unsupportedSection)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s section: %w", name, err)
+		}
+		if tcf, ok := decoded.(iab_tcf.Consent); ok {
+			fields, err := consentRecord(tcf)
+			if err != nil {
+				return nil, fmt.Errorf("decoding %s section: %w", name, err)
+			}
+			sections = sections.add(name, fields)
+			continue
+		}
+		sections = sections.add(name, newRecord(decoded))
+	}
+	return record{{Name: "Format", Value: "GPP"}}.
+		add("Header", newRecord(consent.Header)).
+		add("Sections", sections), nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("decode", func() {
+
+	const (
+		testConsent    = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"
+		testGPPConsent = "DBACNY~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN"
+	)
+
+	var stdout, stderr *bytes.Buffer
+
+	execute := func(stdin string, args ...string) int {
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+		return run(args, strings.NewReader(stdin), stdout, stderr)
+	}
+
+	DescribeTable("detects the format",
+		func(value, expected string) {
+			Expect(detectFormat(value)).To(Equal(expected))
+		},
+		Entry("of TCF strings", testConsent, formatTCF),
+		Entry("of GPP strings", testGPPConsent, formatGPP),
+		Entry("of US Privacy strings", "1YNN", formatUSPrivacy),
+		Entry("of Additional Consent strings", "2~1.35~dv.9", formatAdditionalConsent),
+	)
+
+	It("prints every field of TCF strings as a table", func() {
+		Expect(execute("", "decode", testConsent)).To(Equal(0))
+		Expect(stdout.String()).To(MatchRegexp(`Format\s+TCF v2\n`))
+		Expect(stdout.String()).To(MatchRegexp(`Consent.schemaVersion\s+1\n`))
+		Expect(stdout.String()).To(MatchRegexp(`Consent.created\s+2017-07-14T02:40:00Z\n`))
+		Expect(stdout.String()).To(MatchRegexp(`Consent.cmpVersion\s+1\n`))
+		Expect(stdout.String()).To(MatchRegexp(`Consent.purposes.consents\s+1-4\n`))
+		Expect(stdout.String()).To(MatchRegexp(`Consent.publisherRestrictions\[0\].vendorRanges\[0\].endVendorId\s+5\n`))
+	})
+
+	It("prints every field as JSON with the schema of the consents", func() {
+		Expect(execute("", "decode", "-json", testConsent)).To(Equal(0))
+		decoded := struct {
+			Format  string
+			Consent json.RawMessage
+		}{}
+		Expect(json.Unmarshal(stdout.Bytes(), &decoded)).To(Succeed())
+		Expect(decoded.Format).To(Equal("TCF v2"))
+		consent, err := iab_tcf.Unmarshal(decoded.Consent)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.CMPID()).To(Equal(10))
+		Expect(consent.(iab_tcf.SpecialFeatureConsent).HasSpecialFeatureOptIn(1)).To(BeTrue())
+	})
+
+	It("skips the segments of TCF strings that can't be decoded, like the library", func() {
+		Expect(execute("", "decode", testConsent+".IF5E")).To(Equal(0))
+		Expect(stdout.String()).To(MatchRegexp(`Consent.cmpId\s+10\n`))
+		Expect(stdout.String()).NotTo(ContainSubstring("segments."))
+	})
+
+	It("prints the sections of GPP strings", func() {
+		Expect(execute("", "decode", testGPPConsent)).To(Equal(0))
+		Expect(stdout.String()).To(MatchRegexp(`Sections.tcfeuv2.cmpId\s+31\n`))
+		Expect(stdout.String()).To(MatchRegexp(`Sections.uspv1.OptOutSale\s+N\n`))
+	})
+
+	It("prints the TCF Canada sections of GPP strings", func() {
+		Expect(execute("", "decode", "DBABD~BO5rKAAO5rKAAAyACDENAwCQAYAAAGAAAAAVACgAEAAgACgAGBAAgoAMAAwAEA")).To(Equal(0))
+		Expect(stdout.String()).To(MatchRegexp(`Sections.tcfcav1.sectionVersion\s+1\n`))
+		Expect(stdout.String()).To(MatchRegexp(`Sections.tcfcav1.vendors.expressConsents.ids\s+2-4,10\n`))
+		Expect(stdout.String()).NotTo(ContainSubstring("ParsedConsent"))
+	})
+
+	It("reports the unsupported sections of GPP strings", func() {
+		Expect(execute("", "decode", "DBACGM~BOlLbqtOlLbqtAVABADECg-AAAApp7v~1YNN")).To(Equal(0))
+		Expect(stdout.String()).To(MatchRegexp(`Sections.section3\s+unsupported\n`))
+		Expect(stdout.String()).To(MatchRegexp(`Sections.uspv1.OptOutSale\s+N\n`))
+	})
+
+	It("reads the strings from the standard input", func() {
+		Expect(execute("1YNN\n\n1~1.35\n", "decode")).To(Equal(0))
+		Expect(stdout.String()).To(MatchRegexp(`Format\s+US Privacy\n`))
+		Expect(stdout.String()).To(MatchRegexp(`Consent.Consented\s+1,35\n`))
+	})
+
+	It("uses the format requested", func() {
+		Expect(execute("", "decode", "-format", "usp", testConsent)).To(Equal(1))
+		Expect(stderr.String()).To(ContainSubstring(testConsent))
+	})
+
+	It("fails with invalid strings", func() {
+		Expect(execute("", "decode", "invalid", "1YNN")).To(Equal(1))
+		Expect(stderr.String()).To(HavePrefix("invalid: "))
+		Expect(stdout.String()).To(MatchRegexp(`Format\s+US Privacy\n`))
+	})
+
+	It("fails with unknown commands", func() {
+		Expect(execute("", "unknown")).To(Equal(2))
+		Expect(stderr.String()).To(ContainSubstring("Unknown command"))
+	})
+})
+
+var _ = Describe("formatIDs", func() {
+
+	It("groups consecutive IDs in ranges", func() {
+		Expect(formatIDs([]int{1, 2, 3, 5, 7, 8})).To(Equal("1-3,5,7-8"))
+		Expect(formatIDs([]int{})).To(Equal("-"))
+	})
+})
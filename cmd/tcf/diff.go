@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+)
+
+// runDiff prints the changes between two TCF consent strings. Like diff, it exits with 0
+// when there are no changes, 1 when there are changes and 2 if something went wrong.
+func runDiff(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	asJSON := flags.Bool("json", false, "print the changes as JSON")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		fmt.Fprintln(stderr, "Usage: tcf diff [-json] <from> <to>")
+		return 2
+	}
+	consents := make([]iab_tcf.Consent, 2)
+	for i, value := range flags.Args() {
+		consent, err := iab_tcf.NewConsent(value)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", value, err)
+			return 2
+		}
+		consents[i] = consent
+	}
+	d := iab_tcf.Diff(consents[0], consents[1])
+	var err error
+	if *asJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(d)
+	} else {
+		err = writeTable(stdout, diffRecord(d))
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if d.IsEmpty() {
+		return 0
+	}
+	return 1
+}
+
+// diffRecord returns the record of the non empty changes, grouping the vendors of the
+// publisher restrictions by purpose and restriction type.
+func diffRecord(d *iab_tcf.ConsentDiff) record {
+	metadata := record{}
+	for _, change := range d.Metadata {
+		metadata = metadata.add(change.Field, fmt.Sprintf("%s -> %s", change.From, change.To))
+	}
+	r := record{}
+	if len(metadata) > 0 {
+		r = r.add("Metadata", metadata)
+	}
+	for _, changes := range []struct {
+		name string
+		ids  iab_tcf.IDChanges
+	}{
+		{"PurposeConsents", d.PurposeConsents},
+		{"PurposeLegitimateInterests", d.PurposeLegitimateInterests},
+		{"SpecialFeatureOptIns", d.SpecialFeatureOptIns},
+		{"VendorConsents", d.VendorConsents},
+		{"VendorLegitimateInterests", d.VendorLegitimateInterests},
+	} {
+		if !changes.ids.IsEmpty() {
+			r = r.add(changes.name, record{{"Added", changes.ids.Added}, {"Removed", changes.ids.Removed}})
+		}
+	}
+	for _, restrictions := range []struct {
+		name         string
+		restrictions []iab_tcf.Restriction
+	}{
+		{"AddedRestrictions", d.AddedRestrictions},
+		{"RemovedRestrictions", d.RemovedRestrictions},
+	} {
+		grouped := record{}
+		for _, restriction := range restrictions.restrictions {
+			name := fmt.Sprintf("Purpose%d.Type%d", restriction.PurposeID, restriction.RestrictionType)
+			vendors := strconv.Itoa(restriction.StartVendorID)
+			if restriction.EndVendorID != restriction.StartVendorID {
+				vendors += "-" + strconv.Itoa(restriction.EndVendorID)
+			}
+			if len(grouped) > 0 && grouped[len(grouped)-1].Name == name {
+				last := &grouped[len(grouped)-1]
+				last.Value = last.Value.(string) + "," + vendors
+			} else {
+				grouped = grouped.add(name, vendors)
+			}
+		}
+		if len(grouped) > 0 {
+			r = r.add(restrictions.name, grouped)
+		}
+	}
+	return r
+}
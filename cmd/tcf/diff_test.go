@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("diff", func() {
+
+	const (
+		testConsent   = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"
+		testNoConsent = "CN-EdYAN-EdYAAKABBENAyCAAHAAAAAAAAhoAFAgAAAAAA"
+	)
+
+	var stdout, stderr *bytes.Buffer
+
+	execute := func(args ...string) int {
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+		return run(append([]string{"diff"}, args...), strings.NewReader(""), stdout, stderr)
+	}
+
+	It("prints the changes as a table", func() {
+		Expect(execute(testConsent, testNoConsent)).To(Equal(1))
+		Expect(stdout.String()).To(MatchRegexp(`PurposeConsents.Removed\s+1\n`))
+		Expect(stdout.String()).To(MatchRegexp(`SpecialFeatureOptIns.Removed\s+1\n`))
+		Expect(stdout.String()).To(MatchRegexp(`RemovedRestrictions.Purpose1.Type0\s+5\n`))
+		Expect(stdout.String()).NotTo(ContainSubstring("VendorConsents"))
+	})
+
+	It("prints the changes as JSON", func() {
+		Expect(execute("-json", testNoConsent, testConsent)).To(Equal(1))
+		d := map[string]interface{}{}
+		Expect(json.Unmarshal(stdout.Bytes(), &d)).To(Succeed())
+		Expect(d["PurposeConsents"]).To(HaveKeyWithValue("Added", ConsistOf(BeEquivalentTo(1))))
+		Expect(d["AddedRestrictions"]).To(HaveLen(1))
+	})
+
+	It("exits with 0 without changes", func() {
+		Expect(execute(testConsent, testConsent)).To(Equal(0))
+		Expect(stdout.String()).To(BeEmpty())
+	})
+
+	It("fails with invalid strings", func() {
+		Expect(execute(testConsent, "invalid")).To(Equal(2))
+		Expect(stderr.String()).To(HavePrefix("invalid: "))
+		Expect(execute(testConsent)).To(Equal(2))
+	})
+})
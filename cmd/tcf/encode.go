@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/addtlconsent"
+	"github.com/hybridtheory/iab-tcf/usprivacy"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnsupportedEncoding is returned when the format of the description can't be encoded.
+var ErrUnsupportedEncoding = errors.New("Unsupported format to encode")
+
+// spec is the description of a consent string, with the same schema as the decoder output:
+// the JSON schema of the consents for TCF strings and the fields of the consents otherwise.
+type spec struct {
+	Format  string                 `yaml:"Format"`
+	Consent map[string]interface{} `yaml:"Consent"`
+}
+
+// runEncode prints the consent string of every JSON or YAML description received.
+func runEncode(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("encode", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	code := 0
+	for _, file := range files {
+		var data []byte
+		var err error
+		if file == "-" {
+			data, err = io.ReadAll(stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err == nil {
+			var value string
+			if value, err = encode(data); err == nil {
+				fmt.Fprintln(stdout, value)
+				continue
+			}
+		}
+		fmt.Fprintf(stderr, "%s: %s\n", file, err)
+		code = 1
+	}
+	return code
+}
+
+// encode returns the consent string of the JSON or YAML description received.
+func encode(data []byte) (string, error) {
+	description := spec{}
+	if err := yaml.Unmarshal(data, &description); err != nil {
+		return "", err
+	}
+	switch description.Format {
+	case "TCF v1", "TCF v2", "":
+		data, err := json.Marshal(description.Consent)
+		if err != nil {
+			return "", err
+		}
+		consent, err := iab_tcf.Unmarshal(data)
+		if err != nil {
+			return "", err
+		}
+		switch c := consent.(type) {
+		case *iab_tcf.ConsentV1:
+			return iab_tcf.EncodeV1(c.ToParsedConsent())
+		case *iab_tcf.ConsentV2:
+			return iab_tcf.EncodeV2(c.ToParsedConsent())
+		}
+	case "US Privacy":
+		consent := &usprivacy.Consent{}
+		if err := assign(reflect.ValueOf(consent).Elem(), description.Consent, ""); err != nil {
+			return "", err
+		}
+		return consent.Encode()
+	case "Additional Consent":
+		consent := &addtlconsent.Consent{}
+		if err := assign(reflect.ValueOf(consent).Elem(), description.Consent, ""); err != nil {
+			return "", err
+		}
+		return consent.String(), nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedEncoding, description.Format)
+}
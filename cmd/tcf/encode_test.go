@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("encode", func() {
+
+	var stdout, stderr *bytes.Buffer
+
+	execute := func(stdin string, args ...string) int {
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+		return run(args, strings.NewReader(stdin), stdout, stderr)
+	}
+
+	DescribeTable("encodes the decoder JSON output back to the same string",
+		func(value string) {
+			Expect(execute("", "decode", "-json", value)).To(Equal(0))
+			Expect(execute(stdout.String(), "encode")).To(Equal(0))
+			Expect(stdout.String()).To(Equal(value + "\n"))
+		},
+		Entry("of TCF v2 strings with segments", "COytyllOytyllCrAAAENAiCMAFVAACqAAAAAF3QAgAFABkAAoioAAA.IF5EX2S5OI2tho2YdF7BEYYwfJxyigMgShgQIsS8NwIeFbBoGPmAAHBG4JAQAGBAkkACBAQIsHGBcCQABgIgRiRCMQEGMjzNKBJBAggkbI0FACCVmnkHS3ZCY70-6u__bA"),
+		Entry("of TCF v2 strings with publisher restrictions", "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"),
+		Entry("of TCF v1 strings", "BOlLbqtOlLbqtAVABADECg-AAAApp7v______9______9uz_Ov_v_f__33e8__9v_l_7_-___u_-3zd4u_1vf99yfm1-7etr3tp_87ues2_Xur__79__3z3_9phP78k89r7337Ew-v02"),
+		Entry("of US Privacy strings", "1YNN"),
+		Entry("of Additional Consent strings", "2~1.35~dv.9"),
+	)
+
+	It("encodes YAML descriptions", func() {
+		Expect(execute(`
+Format: TCF v2
+Consent:
+  schemaVersion: 1
+  tcfVersion: 2
+  created: 2020-05-01T09:23:22.1Z
+  lastUpdated: "2020-05-01T09:23:22.1Z"
+  cmpId: 10
+  consentLanguage: EN
+  publisherCC: ES
+  tcfPolicyVersion: 2
+  purposeOneTreatment: true
+  purposes: {consents: [1, 2, 3]}
+  vendors:
+    consents: {maxVendorId: 100, isRangeEncoding: true, ids: [5, 6, 7, 100]}
+  publisherRestrictions:
+    - {purposeId: 2, restrictionType: 1, vendorRanges: [{startVendorId: 7, endVendorId: 7}]}
+`, "encode")).To(Equal(0))
+		value := strings.TrimSpace(stdout.String())
+		Expect(execute("", "decode", value)).To(Equal(0))
+		Expect(stdout.String()).To(MatchRegexp(`Consent.created\s+2020-05-01T09:23:22.1Z\n`))
+		Expect(stdout.String()).To(MatchRegexp(`Consent.purposeOneTreatment\s+true\n`))
+		Expect(stdout.String()).To(MatchRegexp(`Consent.vendors.consents.ids\s+5-7,100\n`))
+		Expect(stdout.String()).To(MatchRegexp(`Consent.publisherRestrictions\[0\].restrictionType\s+1\n`))
+	})
+
+	DescribeTable("fails with invalid descriptions",
+		func(description, message string) {
+			Expect(execute(description, "encode")).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring(message))
+		},
+		Entry("with unknown fields", "Format: US Privacy\nConsent: {OptOut: N}", `unknown field "OptOut"`),
+		Entry("with unsupported schema versions", "Consent: {schemaVersion: 2}", "Unsupported JSON schema version: 2"),
+		Entry("with wrong types", "Consent: {schemaVersion: 1, tcfVersion: 2, purposes: {consents: 3}}", "cannot unmarshal number"),
+		Entry("with values out of range", "Consent: {schemaVersion: 1, tcfVersion: 2, cmpId: 5000, consentLanguage: EN, publisherCC: ES}", "CMP ID 5000"),
+		Entry("with unsupported formats", "Format: GPP", "Unsupported format"),
+	)
+})
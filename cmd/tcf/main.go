@@ -0,0 +1,121 @@
+// Command tcf decodes consent strings offline, printing every one of their fields.
+//
+// Usage:
+//
+//	tcf decode [-json] [-format auto|tcf|gpp|usp|ac] [string...]
+//	tcf batch [-input file] [-column n] [-delimiter ,] [-header] [-workers n] [-format auto|tcf|gpp|usp|ac] [-output jsonl|csv|parquet]
+//	tcf encode [file...]
+//	tcf diff [-json] <from> <to>
+//
+// The decode command reads the strings from the standard input, one per line, when none
+// is passed. The batch command decodes every line of the input concurrently and writes a
+// JSON line with the decoded fields, or the error classification, of each one, or a CSV
+// or Parquet row per TCF consent. The encode command reads JSON or YAML descriptions with
+// the same schema as the decoder JSON output and prints their consent strings. The diff
+// command prints the changes between two TCF consent strings.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const usage = `Usage: tcf <command> [flags] [string...]
+
+Commands:
+  decode    prints every field of the consent strings
+  batch     decodes every line of a log file as JSON lines
+  encode    prints the consent strings of JSON or YAML descriptions
+  diff      prints the changes between two TCF consent strings
+
+Run 'tcf <command> -h' to list the flags of a command.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command of the arguments received and returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "decode":
+		return runDecode(args[1:], stdin, stdout, stderr)
+	case "batch":
+		return runBatch(args[1:], stdin, stdout, stderr)
+	case "encode":
+		return runEncode(args[1:], stdin, stdout, stderr)
+	case "diff":
+		return runDiff(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+	fmt.Fprintf(stderr, "Unknown command %q\n\n%s", args[0], usage)
+	return 2
+}
+
+// runDecode prints every field of the consent strings as a table or as JSON.
+func runDecode(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("decode", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	asJSON := flags.Bool("json", false, "print the fields as JSON")
+	format := flags.String("format", formatAuto, "format of the strings: auto, tcf, gpp, usp or ac")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	values, err := inputs(flags.Args(), stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	code := 0
+	for i, value := range values {
+		r, err := decode(value, *format)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", value, err)
+			code = 1
+			continue
+		}
+		if *asJSON {
+			encoder := json.NewEncoder(stdout)
+			encoder.SetIndent("", "  ")
+			err = encoder.Encode(r)
+		} else {
+			if i > 0 {
+				fmt.Fprintln(stdout)
+			}
+			err = writeTable(stdout, r)
+		}
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+	return code
+}
+
+// inputs returns the strings passed as arguments, or the non empty lines of the
+// standard input if there are none.
+func inputs(args []string, stdin io.Reader) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	values := []string{}
+	scanner := bufio.NewScanner(stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			values = append(values, line)
+		}
+	}
+	return values, scanner.Err()
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hybridtheory/iab-tcf/usprivacy"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	flagType = reflect.TypeOf(usprivacy.Flag(0))
+)
+
+// field is a named value of a decoded string.
+type field struct {
+	Name  string
+	Value interface{}
+}
+
+// record is the list of fields of a decoded string, in the order they are encoded.
+type record []field
+
+// MarshalJSON encodes the record as a JSON object keeping the order of the fields.
+func (r record) MarshalJSON() ([]byte, error) {
+	buffer := bytes.NewBufferString("{")
+	for i, f := range r {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		name, _ := json.Marshal(f.Name)
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// add appends a field to the record, skipping the empty ones.
+func (r record) add(name string, value interface{}) record {
+	if value == nil {
+		return r
+	}
+	return append(r, field{Name: name, Value: value})
+}
+
+// newRecord returns the record of the exported fields of the parsed consent received.
+// Types returning the parsed consent through a `ToParsedConsent` method, or embedding it
+// in a `ParsedConsent` field, are unwrapped first.
+func newRecord(parsed interface{}) record {
+	v := reflect.ValueOf(parsed)
+	if method := v.MethodByName("ToParsedConsent"); method.IsValid() && method.Type().NumIn() == 0 {
+		v = method.Call(nil)[0]
+	}
+	v = reflect.Indirect(v)
+	if v.Kind() == reflect.Struct {
+		if inner := v.FieldByName("ParsedConsent"); inner.IsValid() {
+			v = reflect.Indirect(inner)
+		}
+	}
+	r, _ := convert(v).(record)
+	return r
+}
+
+// newJSONRecord returns the record of a JSON object, keeping the order of its fields.
+// Lists of integers are returned as IDs, so they're printed grouped in ranges.
+func newJSONRecord(data []byte) (record, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := readJSON(decoder)
+	if err != nil {
+		return nil, err
+	}
+	r, _ := value.(record)
+	return r, nil
+}
+
+// readJSON returns the next value of the decoder as a type the record can print.
+func readJSON(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := token.(type) {
+	case json.Delim:
+		if t == '{' {
+			r := record{}
+			for decoder.More() {
+				name, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := readJSON(decoder)
+				if err != nil {
+					return nil, err
+				}
+				r = r.add(name.(string), value)
+			}
+			_, err = decoder.Token()
+			return r, err
+		}
+		values := []interface{}{}
+		ids := []int{}
+		for decoder.More() {
+			value, err := readJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if id, ok := value.(int); ok && ids != nil {
+				ids = append(ids, id)
+			} else {
+				ids = nil
+			}
+			values = append(values, value)
+		}
+		if _, err = decoder.Token(); err != nil {
+			return nil, err
+		}
+		if ids != nil {
+			return ids, nil
+		}
+		return values, nil
+	case json.Number:
+		if n, err := t.Int64(); err == nil {
+			return int(n), nil
+		}
+	}
+	return token, nil
+}
+
+// convert returns the value received as a type the record can print: records for
+// structs, sorted IDs for sets of IDs and plain values otherwise.
+func convert(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return convert(v.Elem())
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface()
+		}
+		r := record{}
+		for i := 0; i < v.NumField(); i++ {
+			if structField := v.Type().Field(i); structField.IsExported() {
+				r = r.add(structField.Name, convert(v.Field(i)))
+			}
+		}
+		return r
+	case reflect.Map:
+		return convertMap(v)
+	case reflect.Slice:
+		values := make([]interface{}, v.Len())
+		for i := range values {
+			values[i] = convert(v.Index(i))
+		}
+		return values
+	}
+	if v.Type() == flagType {
+		return string(rune(v.Uint()))
+	}
+	if v.CanInt() {
+		return int(v.Int())
+	}
+	return v.Interface()
+}
+
+// convertMap returns the sorted IDs set to true of the sets of IDs, and a record with
+// the values sorted by ID of the rest of the maps indexed by ID.
+func convertMap(v reflect.Value) interface{} {
+	keys := make([]int, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		keys = append(keys, int(key.Int()))
+	}
+	sort.Ints(keys)
+	if v.Type().Elem().Kind() == reflect.Bool {
+		ids := []int{}
+		for _, key := range keys {
+			if v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())).Bool() {
+				ids = append(ids, key)
+			}
+		}
+		return ids
+	}
+	r := record{}
+	for _, key := range keys {
+		r = r.add(strconv.Itoa(key), convert(v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))))
+	}
+	return r
+}
+
+// writeTable prints the record as a two column table, flattening the nested fields.
+func writeTable(w io.Writer, r record) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	writeRows(table, "", r)
+	return table.Flush()
+}
+
+// writeRows prints a row for every field of the record, prefixing their names.
+func writeRows(w io.Writer, prefix string, r record) {
+	for _, f := range r {
+		writeRow(w, prefix+f.Name, f.Value)
+	}
+}
+
+// writeRow prints a row for the value, or a row for each one of its fields if it's a record.
+func writeRow(w io.Writer, name string, value interface{}) {
+	switch v := value.(type) {
+	case record:
+		writeRows(w, name+".", v)
+	case []interface{}:
+		if len(v) == 0 {
+			fmt.Fprintf(w, "%s\t-\n", name)
+		}
+		for i, item := range v {
+			writeRow(w, fmt.Sprintf("%s[%d]", name, i), item)
+		}
+	case []int:
+		fmt.Fprintf(w, "%s\t%s\n", name, formatIDs(v))
+	case time.Time:
+		fmt.Fprintf(w, "%s\t%s\n", name, v.Format(time.RFC3339))
+	case string:
+		if v == "" {
+			v = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", name, v)
+	default:
+		fmt.Fprintf(w, "%s\t%v\n", name, v)
+	}
+}
+
+// formatIDs returns the sorted IDs received grouping the consecutive ones in ranges,
+// e.g. `1-3,5`.
+func formatIDs(ids []int) string {
+	if len(ids) == 0 {
+		return "-"
+	}
+	parts := []string{}
+	for i := 0; i < len(ids); i++ {
+		start := ids[i]
+		for i+1 < len(ids) && ids[i+1] == ids[i]+1 {
+			i++
+		}
+		if start == ids[i] {
+			parts = append(parts, strconv.Itoa(start))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", start, ids[i]))
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// assign sets the target with the data of a JSON or YAML description, following the same
+// schema the records are printed with: objects for structs, lists of IDs for sets of IDs
+// and objects indexed by ID for the rest of the maps indexed by ID.
+func assign(target reflect.Value, data interface{}, path string) error {
+	if data == nil {
+		return nil
+	}
+	switch target.Kind() {
+	case reflect.Pointer:
+		value := reflect.New(target.Type().Elem())
+		if err := assign(value.Elem(), data, path); err != nil {
+			return err
+		}
+		target.Set(value)
+		return nil
+	case reflect.Struct:
+		if target.Type() == timeType {
+			return assignTime(target, data, path)
+		}
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			return specError(path, "an object", data)
+		}
+		for name, value := range object {
+			structField, ok := target.Type().FieldByName(name)
+			if !ok || !structField.IsExported() {
+				return fmt.Errorf("%s: unknown field %q", pathName(path), name)
+			}
+			if err := assign(target.FieldByIndex(structField.Index), value, path+"."+name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		return assignMap(target, data, path)
+	case reflect.Slice:
+		list, ok := data.([]interface{})
+		if !ok {
+			return specError(path, "a list", data)
+		}
+		slice := reflect.MakeSlice(target.Type(), len(list), len(list))
+		for i, item := range list {
+			if err := assign(slice.Index(i), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		target.Set(slice)
+		return nil
+	case reflect.Bool:
+		value, ok := data.(bool)
+		if !ok {
+			return specError(path, "a boolean", data)
+		}
+		target.SetBool(value)
+		return nil
+	case reflect.String:
+		value, ok := data.(string)
+		if !ok {
+			return specError(path, "a string", data)
+		}
+		target.SetString(value)
+		return nil
+	}
+	if target.Type() == flagType {
+		value, ok := data.(string)
+		if !ok || len(value) != 1 {
+			return specError(path, "a single character", data)
+		}
+		target.SetUint(uint64(value[0]))
+		return nil
+	}
+	if target.CanInt() {
+		value, ok := toInt(data)
+		if !ok {
+			return specError(path, "an integer", data)
+		}
+		target.SetInt(int64(value))
+		return nil
+	}
+	return fmt.Errorf("%s: unsupported field", pathName(path))
+}
+
+// assignTime sets the time target with an RFC 3339 timestamp.
+func assignTime(target reflect.Value, data interface{}, path string) error {
+	switch value := data.(type) {
+	case time.Time:
+		target.Set(reflect.ValueOf(value.UTC()))
+		return nil
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", pathName(path), err)
+		}
+		target.Set(reflect.ValueOf(parsed.UTC()))
+		return nil
+	}
+	return specError(path, "an RFC 3339 timestamp", data)
+}
+
+// assignMap sets the map target with a list of IDs for sets of IDs, or with an object
+// indexed by ID for the rest of the maps.
+func assignMap(target reflect.Value, data interface{}, path string) error {
+	keyType, elemType := target.Type().Key(), target.Type().Elem()
+	values := reflect.MakeMap(target.Type())
+	if elemType.Kind() == reflect.Bool {
+		list, ok := data.([]interface{})
+		if !ok {
+			return specError(path, "a list of IDs", data)
+		}
+		for _, item := range list {
+			id, ok := toInt(item)
+			if !ok {
+				return specError(path, "a list of IDs", data)
+			}
+			values.SetMapIndex(reflect.ValueOf(id).Convert(keyType), reflect.ValueOf(true))
+		}
+	} else {
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			return specError(path, "an object indexed by ID", data)
+		}
+		for key, item := range object {
+			id, err := strconv.Atoi(key)
+			if err != nil {
+				return specError(path, "an object indexed by ID", data)
+			}
+			value := reflect.New(elemType).Elem()
+			if err := assign(value, item, path+"."+key); err != nil {
+				return err
+			}
+			values.SetMapIndex(reflect.ValueOf(id).Convert(keyType), value)
+		}
+	}
+	target.Set(values)
+	return nil
+}
+
+// toInt returns the integer value of a JSON or YAML number.
+func toInt(data interface{}) (int, bool) {
+	switch value := data.(type) {
+	case int:
+		return value, true
+	case float64:
+		if value == float64(int(value)) {
+			return int(value), true
+		}
+	}
+	return 0, false
+}
+
+// specError returns the error of a field with a value of the wrong type.
+func specError(path, expected string, data interface{}) error {
+	return fmt.Errorf("%s: expected %s, found %v", pathName(path), expected, data)
+}
+
+// pathName returns the path of a field without the leading dot.
+func pathName(path string) string {
+	if path == "" {
+		return "spec"
+	}
+	return path[1:]
+}
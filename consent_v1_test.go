@@ -1,6 +1,7 @@
 package iab_tcf_test
 
 import (
+	"github.com/LiveRamp/iabconsent"
 	iab_tcf "github.com/hybridtheory/iab-tcf"
 	"github.com/hybridtheory/iab-tcf/cmp"
 	. "github.com/onsi/ginkgo/v2"
@@ -31,6 +32,15 @@ var _ = Describe("Consent TCF 1.0", func() {
 		Expect(consent.Version()).To(Equal(1))
 	})
 
+	It("returns ErrUnexpectedEnd with truncated strings", func() {
+		_, err := iab_tcf.NewConsent("BOlLbqtOlLbqtAVABADECg")
+		Expect(err).To(MatchError(iab_tcf.ErrUnexpectedEnd))
+	})
+
+	It("keeps the consent string it was decoded from", func() {
+		Expect(consent.(*iab_tcf.ConsentV1).ConsentString()).To(Equal(testGdprConsent))
+	})
+
 	It("detects the cmp id as 21", func() {
 		Expect(consent.CMPID()).To(Equal(21))
 	})
@@ -60,6 +70,10 @@ var _ = Describe("Consent TCF 1.0", func() {
 		Expect(consent.GetConsentPurposeBitstring()).To(Equal(testBitstringConsentPurpose))
 	})
 
+	It("returns always false for 'HasSpecialFeatureOptIn' because TCF 1.0 does not contain that info", func() {
+		Expect(consent.(iab_tcf.SpecialFeatureConsent).HasSpecialFeatureOptIn(1)).To(BeFalse())
+	})
+
 	It("returns always true for 'HasConsentedLegitimateInterestForPurpose' because TCF 1.0 does not contain that info", func() {
 		for purposeID := 1; purposeID <= 24; purposeID++ {
 			Expect(consent.HasConsentedLegitimateInterestForPurpose(purposeID)).To(BeTrue())
@@ -96,6 +110,49 @@ var _ = Describe("Consent TCF 1.0", func() {
 		Expect(consent.GetConsentBitstring()).To(Equal(testBitstringConsent))
 	})
 
+	It("returns the metadata through accessors", func() {
+		c := consent.(*iab_tcf.ConsentV1)
+		Expect(c.ConsentLanguage()).To(Equal(c.ToParsedConsent().ConsentLanguage))
+		Expect(c.VendorListVersion()).To(Equal(c.ToParsedConsent().VendorListVersion))
+		Expect(c.MaxVendorID()).To(Equal(len(testBitstringConsent)))
+		Expect(c.Created()).NotTo(BeZero())
+	})
+
+	It("can't be modified through the values returned", func() {
+		c := consent.(*iab_tcf.ConsentV1)
+		c.ToParsedConsent().PurposesAllowed[24] = !c.HasConsentedPurpose(24)
+		Expect(c.ToParsedConsent().PurposesAllowed[24]).To(Equal(c.HasConsentedPurpose(24)))
+		Expect(c.GetConsentPurposeBitstring()).To(Equal(testBitstringConsentPurpose))
+	})
+
+	It("appends the consent bits to a buffer", func() {
+		Expect(string(iab_tcf.AppendConsentBits([]byte("bits:"), consent))).To(Equal("bits:" + testBitstringConsent))
+		Expect(string(iab_tcf.AppendConsentPurposeBits(nil, consent))).To(Equal(testBitstringConsentPurpose))
+		Expect(iab_tcf.AppendInterestsBits(nil, consent)).To(BeEmpty())
+	})
+
+	It("returns the consent bitstring of range encoded consent strings", func() {
+		for _, defaultConsent := range []bool{false, true} {
+			encoded, err := iab_tcf.EncodeV1(&iabconsent.ParsedConsent{
+				ConsentLanguage: "EN",
+				MaxVendorID:     70,
+				IsRangeEncoding: true,
+				DefaultConsent:  defaultConsent,
+				RangeEntries:    []*iabconsent.RangeEntry{{StartVendorID: 2, EndVendorID: 2}, {StartVendorID: 60, EndVendorID: 66}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			rangeConsent, err := iab_tcf.NewConsent(encoded)
+			Expect(err).NotTo(HaveOccurred())
+			expected := ""
+			for vendorID := 1; vendorID <= 70; vendorID++ {
+				expected += map[bool]string{true: "1", false: "0"}[rangeConsent.HasUserConsented(vendorID)]
+			}
+			Expect(rangeConsent.GetConsentBitstring()).To(Equal(expected))
+			Expect(string(iab_tcf.AppendConsentBits(nil, rangeConsent))).To(Equal(expected))
+			Expect(expected[1:2]).To(Equal(map[bool]string{true: "0", false: "1"}[defaultConsent]))
+		}
+	})
+
 	It("always returns the user interest bitstring as empty because TCF 1.0 does not contain that info", func() {
 		Expect(consent.GetInterestsBitstring()).To(Equal(""))
 	})
@@ -1,6 +1,14 @@
 package iab_tcf_test
 
 import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LiveRamp/iabconsent"
+
 	iab_tcf "github.com/hybridtheory/iab-tcf"
 	"github.com/hybridtheory/iab-tcf/cmp"
 	. "github.com/onsi/ginkgo/v2"
@@ -32,6 +40,10 @@ var _ = Describe("Consent TCF 2.0", func() {
 		Expect(consent.Version()).To(Equal(2))
 	})
 
+	It("keeps the consent string it was decoded from", func() {
+		Expect(consent.(*iab_tcf.ConsentV2).ConsentString()).To(Equal(testGdprConsent))
+	})
+
 	It("detects the CMP ID as 21", func() {
 		Expect(consent.CMPID()).To(Equal(92))
 	})
@@ -121,6 +133,19 @@ var _ = Describe("Consent TCF 2.0", func() {
 		Expect(consent.GetInterestsBitstring()).To(Equal(testBitstringInterestConsent))
 	})
 
+	It("appends the bits to a buffer", func() {
+		Expect(string(iab_tcf.AppendConsentBits([]byte("bits:"), consent))).To(Equal("bits:" + testBitstringConsent))
+		Expect(string(iab_tcf.AppendInterestsBits(nil, consent))).To(Equal(testBitstringInterestConsent))
+		Expect(string(iab_tcf.AppendConsentPurposeBits(nil, consent))).To(Equal(testBitstringConsentPurpose))
+	})
+
+	It("appends the bitstrings of consents without the append methods", func() {
+		external := struct{ iab_tcf.Consent }{consent}
+		Expect(string(iab_tcf.AppendConsentBits([]byte("bits:"), external))).To(Equal("bits:" + testBitstringConsent))
+		Expect(string(iab_tcf.AppendInterestsBits(nil, external))).To(Equal(testBitstringInterestConsent))
+		Expect(string(iab_tcf.AppendConsentPurposeBits(nil, external))).To(Equal(testBitstringConsentPurpose))
+	})
+
 	It("returns the publisher restrictions", func() {
 		Expect(consent.GetPublisherRestrictions()).To(HaveLen(0))
 	})
@@ -149,6 +174,58 @@ var _ = Describe("Consent TCF 2.0 generated with https://iabtcf.com/#/encode", f
 		Expect(consent.Version()).To(Equal(2))
 	})
 
+	It("decodes the metadata of the core segment", func() {
+		parsedConsent := consent.(*iab_tcf.ConsentV2).ToParsedConsent()
+		Expect(parsedConsent.Created).To(Equal(time.Date(2020, 5, 1, 9, 23, 22, 100000000, time.UTC)))
+		Expect(parsedConsent.LastUpdated).To(Equal(parsedConsent.Created))
+		Expect(parsedConsent.CMPVersion).To(Equal(0))
+		Expect(parsedConsent.ConsentScreen).To(Equal(0))
+	})
+
+	It("returns the metadata through accessors", func() {
+		c := consent.(*iab_tcf.ConsentV2)
+		Expect(c.Created()).To(Equal(time.Date(2020, 5, 1, 9, 23, 22, 100000000, time.UTC)))
+		Expect(c.LastUpdated()).To(Equal(c.Created()))
+		Expect(c.CMPVersion()).To(Equal(0))
+		Expect(c.ConsentScreen()).To(Equal(0))
+		Expect(c.ConsentLanguage()).To(Equal("EN"))
+		Expect(c.VendorListVersion()).To(Equal(34))
+		Expect(c.TCFPolicyVersion()).To(Equal(2))
+		Expect(c.IsServiceSpecific()).To(BeFalse())
+		Expect(c.UseNonStandardStacks()).To(BeFalse())
+		Expect(c.PurposeOneTreatment()).To(BeFalse())
+		Expect(c.PublisherCC()).To(Equal("AA"))
+		Expect(c.MaxConsentVendorID()).To(Equal(len(testBitstringConsent)))
+		Expect(c.MaxInterestsVendorID()).To(Equal(len(testBitstringInterestConsent)))
+		Expect(c.AllowedVendors()).To(BeNil())
+		Expect(c.PublisherTC()).To(BeNil())
+	})
+
+	It("decodes the disclosed vendors segment", func() {
+		disclosedVendors := consent.(*iab_tcf.ConsentV2).DisclosedVendors()
+		Expect(disclosedVendors).NotTo(BeNil())
+		Expect(disclosedVendors.MaxVendorID).To(Equal(754))
+		Expect(disclosedVendors.Vendors[2]).To(BeTrue())
+		Expect(disclosedVendors.Vendors[3]).To(BeFalse())
+	})
+
+	It("can't be modified through the values returned", func() {
+		c := consent.(*iab_tcf.ConsentV2)
+		c.DisclosedVendors().Vendors[3] = true
+		parsedConsent := c.ToParsedConsent()
+		parsedConsent.PurposesConsent[5] = true
+		parsedConsent.OOBDisclosedVendors.Vendors[4] = true
+		Expect(c.DisclosedVendors().Vendors[3]).To(BeFalse())
+		Expect(c.DisclosedVendors().Vendors[4]).To(BeFalse())
+		Expect(c.HasConsentedPurpose(5)).To(BeFalse())
+	})
+
+	It("ignores the segments that can't be decoded", func() {
+		consent, err = iab_tcf.NewConsent(strings.Split(testGdprConsent, ".")[0] + ".invalid")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.(*iab_tcf.ConsentV2).ToParsedConsent().OOBDisclosedVendors).To(BeNil())
+	})
+
 	It("detects the cmp id as 171", func() {
 		Expect(consent.CMPID()).To(Equal(171))
 	})
@@ -181,6 +258,16 @@ var _ = Describe("Consent TCF 2.0 generated with https://iabtcf.com/#/encode", f
 		Expect(consent.GetConsentPurposeBitstring()).To(Equal(testBitstringConsentPurpose))
 	})
 
+	DescribeTable("special features opt in",
+		func(featureID int, expected bool) {
+			Expect(consent.(iab_tcf.SpecialFeatureConsent).HasSpecialFeatureOptIn(featureID)).To(Equal(expected))
+		},
+		Entry("the special feature id 0", 0, false),
+		Entry("the special feature id 1", 1, true),
+		Entry("the special feature id 2", 2, true),
+		Entry("the special feature id 3", 3, false),
+	)
+
 	DescribeTable("legitimate interest for purposes",
 		func(purposeID int, expected bool) {
 			Expect(consent.HasConsentedLegitimateInterestForPurpose(purposeID)).To(Equal(expected))
@@ -232,3 +319,135 @@ var _ = Describe("Consent TCF 2.0 generated with https://iabtcf.com/#/encode", f
 		Expect(consent.GetPublisherRestrictions()).To(HaveLen(0))
 	})
 })
+
+var _ = Describe("Consent TCF 2.0 native parser", func() {
+
+	DescribeTable("decodes the same values as the iabconsent reader",
+		func(value string) {
+			native, err := iab_tcf.DecodeConsentV2(value)
+			Expect(err).NotTo(HaveOccurred())
+			decoded, err := iab_tcf.DecodeConsent(value)
+			Expect(err).NotTo(HaveOccurred())
+			reader := iabconsent.NewConsentReader(decoded)
+			iab_tcf.GetVersion(reader)
+			expected, err := iab_tcf.NewConsentV2(reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(native.CMPID()).To(Equal(expected.CMPID()))
+			Expect(native.GetConsentPurposeBitstring()).To(Equal(expected.GetConsentPurposeBitstring()))
+			Expect(native.GetConsentBitstring()).To(Equal(expected.GetConsentBitstring()))
+			Expect(native.GetInterestsBitstring()).To(Equal(expected.GetInterestsBitstring()))
+			Expect(native.GetPublisherRestrictions()).To(HaveLen(len(expected.GetPublisherRestrictions())))
+			for i, restriction := range expected.GetPublisherRestrictions() {
+				Expect(native.GetPublisherRestrictions()[i]).To(Equal(restriction))
+			}
+			for id := 0; id <= 25; id++ {
+				Expect(native.HasConsentedLegitimateInterestForPurpose(id)).To(Equal(expected.HasConsentedLegitimateInterestForPurpose(id)))
+				Expect(native.HasSpecialFeatureOptIn(id)).To(Equal(expected.(iab_tcf.SpecialFeatureConsent).HasSpecialFeatureOptIn(id)))
+			}
+			reader = iabconsent.NewConsentReader(decoded)
+			iab_tcf.GetVersion(reader)
+			expectedParsed, err := iab_tcf.ParseV2(reader)
+			Expect(err).NotTo(HaveOccurred())
+			parsed := native.ToParsedConsent()
+			parsed.OOBDisclosedVendors, parsed.OOBAllowedVendors, parsed.PublisherTCEntry = nil, nil, nil
+			Expect(parsed).To(Equal(expectedParsed))
+		},
+		Entry("with a bit field", "COxR03kOxR1CqBcABCENAgCMAP_AAH_AAAqIF3EXySoGY2thI2YVFxBEIYwfJxyigMgChgQIsSwNQIeFLBoGLiAAHBGYJAQAGBAEEACBAQIkHGBMCQAAgAgBiRCMQEGMCzNIBIBAggEbY0FACCVmHkHSmZCY7064O__QLuIJEFQMAkSBAIACLECIQwAQDiAAAYAlAAABAhIaAAgIWBQEeAAAACAwAAgAAABBAAACAAQAAICIAAABAAAgAiAQAAAAGgIQAACBABACRIAAAEANCAAgiCEAQg4EAo4AAA"),
+		Entry("with ranges", "COytyllOytyllCrAAAENAiCMAFVAACqAAAAAF3QAgAFABkAAoioAAA"),
+		Entry("with publisher restrictions", "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"),
+	)
+
+	It("returns copies of the publisher restrictions", func() {
+		consent, err := iab_tcf.NewConsent("CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA")
+		Expect(err).NotTo(HaveOccurred())
+		restrictions := consent.GetPublisherRestrictions()
+		restrictions[0].PurposeID = 2
+		restrictions[0].RestrictionsRange[0].EndVendorID = 10
+		Expect(consent.GetPublisherRestrictions()[0].PurposeID).To(Equal(1))
+		Expect(consent.GetPublisherRestrictions()[0].RestrictionsRange[0].EndVendorID).To(Equal(5))
+	})
+
+	It("returns an error with a truncated consent string", func() {
+		_, err := iab_tcf.DecodeConsentV2("CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAho")
+		Expect(err).To(MatchError(iab_tcf.ErrUnexpectedEnd))
+	})
+
+	DescribeTable("returns an error with an invalid range entry",
+		func(start, end int) {
+			value := encodeRangeConsent(10, [][2]int{{start, end}})
+			_, err := iab_tcf.DecodeConsentV2(value)
+			Expect(err).To(MatchError(iab_tcf.ErrInvalidRange))
+			_, err = iab_tcf.NewConsent(value)
+			Expect(err).To(MatchError(iab_tcf.ErrInvalidRange))
+			decoded, err := iab_tcf.DecodeConsent(value)
+			Expect(err).NotTo(HaveOccurred())
+			reader := iabconsent.NewConsentReader(decoded)
+			iab_tcf.GetVersion(reader)
+			_, err = iab_tcf.NewConsentV2(reader)
+			Expect(err).To(MatchError(iab_tcf.ErrInvalidRange))
+		},
+		Entry("ending before its start", 8, 3),
+		Entry("starting at 0", 0, 3),
+		Entry("ending after the max vendor ID", 3, 11),
+	)
+
+	It("decodes every vendor with the maximum number of ranges quickly", func() {
+		entries := make([][2]int, 1<<12-1)
+		for i := range entries {
+			entries[i] = [2]int{1, 1<<16 - 1}
+		}
+		value := encodeRangeConsent(1<<16-1, entries)
+		start := time.Now()
+		consent, err := iab_tcf.NewConsent(value)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically("<", 100*time.Millisecond))
+		Expect(consent.HasUserConsented(1)).To(BeTrue())
+		Expect(consent.HasUserConsented(64)).To(BeTrue())
+		Expect(consent.HasUserConsented(65)).To(BeTrue())
+		Expect(consent.HasUserConsented(1<<16 - 1)).To(BeTrue())
+		Expect(consent.HasUserConsented(1 << 16)).To(BeFalse())
+	})
+
+	It("returns an error with a TCF 1.0 consent string", func() {
+		_, err := iab_tcf.DecodeConsentV2("BOlLbqtOlLbqtAVABADECg-AAAApp7v______9______9uz_Ov_v_f__33e8__9v_l_7_-___u_-3zd4u_1vf99yfm1-7etr3tp_87ues2_Xur__79__3z3_9phP78k89r7337Ew-v02")
+		Expect(err).To(MatchError(iab_tcf.ErrInvalidVersion))
+	})
+
+	It("allocates just the consent and its vendor bitsets", func() {
+		const value = "COxR03kOxR1CqBcABCENAgCMAP_AAH_AAAqIF3EXySoGY2thI2YVFxBEIYwfJxyigMgChgQIsSwNQIeFLBoGLiAAHBGYJAQAGBAEEACBAQIkHGBMCQAAgAgBiRCMQEGMCzNIBIBAggEbY0FACCVmHkHSmZCY7064O__QLuIJEFQMAkSBAIACLECIQwAQDiAAAYAlAAABAhIaAAgIWBQEeAAAACAwAAgAAABBAAACAAQAAICIAAABAAAgAiAQAAAAGgIQAACBABACRIAAAEANCAAgiCEAQg4EAo4AAA"
+		allocations := testing.AllocsPerRun(100, func() {
+			consent, _ := iab_tcf.NewConsent(value)
+			consent.HasUserConsented(2)
+		})
+		Expect(allocations).To(BeNumerically("<=", 4))
+	})
+})
+
+// encodeRangeConsent returns a TCF 2.0 consent string whose consented vendors are the
+// range entries received, written as is so they can be invalid.
+func encodeRangeConsent(maxVendorID int, entries [][2]int) string {
+	var bits strings.Builder
+	write := func(value, width int) {
+		fmt.Fprintf(&bits, "%0*b", width, value)
+	}
+	write(2, 6)
+	write(0, 36+36+12+12+6)
+	write(0, 12+12+6+1+1+12+24+24+1+12)
+	write(maxVendorID, 16)
+	write(1, 1)
+	write(len(entries), 12)
+	for _, entry := range entries {
+		write(1, 1)
+		write(entry[0], 16)
+		write(entry[1], 16)
+	}
+	write(0, 16+1+12)
+	data := make([]byte, (bits.Len()+7)/8)
+	for i, bit := range bits.String() {
+		if bit == '1' {
+			data[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,1918 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: consent.proto
+
+// Decoded privacy signals, so consumers don't need to decode the original strings.
+// The TCF consents follow the JSON representation of the library, version 1.
+
+package consentpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PrivacySignals groups all the privacy signals sent with a request.
+type PrivacySignals struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tcf               *TCFConsent        `protobuf:"bytes,1,opt,name=tcf,proto3" json:"tcf,omitempty"`
+	UsPrivacy         *USPrivacy         `protobuf:"bytes,2,opt,name=us_privacy,json=usPrivacy,proto3" json:"us_privacy,omitempty"`
+	AdditionalConsent *AdditionalConsent `protobuf:"bytes,3,opt,name=additional_consent,json=additionalConsent,proto3" json:"additional_consent,omitempty"`
+	TcfCanada         *TCFCanadaConsent  `protobuf:"bytes,4,opt,name=tcf_canada,json=tcfCanada,proto3" json:"tcf_canada,omitempty"`
+	UsSections        []*USSection       `protobuf:"bytes,5,rep,name=us_sections,json=usSections,proto3" json:"us_sections,omitempty"`
+}
+
+func (x *PrivacySignals) Reset() {
+	*x = PrivacySignals{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrivacySignals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrivacySignals) ProtoMessage() {}
+
+func (x *PrivacySignals) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrivacySignals.ProtoReflect.Descriptor instead.
+func (*PrivacySignals) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PrivacySignals) GetTcf() *TCFConsent {
+	if x != nil {
+		return x.Tcf
+	}
+	return nil
+}
+
+func (x *PrivacySignals) GetUsPrivacy() *USPrivacy {
+	if x != nil {
+		return x.UsPrivacy
+	}
+	return nil
+}
+
+func (x *PrivacySignals) GetAdditionalConsent() *AdditionalConsent {
+	if x != nil {
+		return x.AdditionalConsent
+	}
+	return nil
+}
+
+func (x *PrivacySignals) GetTcfCanada() *TCFCanadaConsent {
+	if x != nil {
+		return x.TcfCanada
+	}
+	return nil
+}
+
+func (x *PrivacySignals) GetUsSections() []*USSection {
+	if x != nil {
+		return x.UsSections
+	}
+	return nil
+}
+
+// TCFConsent is a decoded TCF 1.0 or 2.0 consent string. The fields marked are only
+// present in TCF 2.0 consents.
+type TCFConsent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TcfVersion uint32 `protobuf:"varint,1,opt,name=tcf_version,json=tcfVersion,proto3" json:"tcf_version,omitempty"`
+	// Timestamps as milliseconds since the Unix epoch, with decisecond precision.
+	CreatedUnixMillis     int64                   `protobuf:"varint,2,opt,name=created_unix_millis,json=createdUnixMillis,proto3" json:"created_unix_millis,omitempty"`
+	LastUpdatedUnixMillis int64                   `protobuf:"varint,3,opt,name=last_updated_unix_millis,json=lastUpdatedUnixMillis,proto3" json:"last_updated_unix_millis,omitempty"`
+	CmpId                 uint32                  `protobuf:"varint,4,opt,name=cmp_id,json=cmpId,proto3" json:"cmp_id,omitempty"`
+	CmpVersion            uint32                  `protobuf:"varint,5,opt,name=cmp_version,json=cmpVersion,proto3" json:"cmp_version,omitempty"`
+	ConsentScreen         uint32                  `protobuf:"varint,6,opt,name=consent_screen,json=consentScreen,proto3" json:"consent_screen,omitempty"`
+	ConsentLanguage       string                  `protobuf:"bytes,7,opt,name=consent_language,json=consentLanguage,proto3" json:"consent_language,omitempty"`
+	VendorListVersion     uint32                  `protobuf:"varint,8,opt,name=vendor_list_version,json=vendorListVersion,proto3" json:"vendor_list_version,omitempty"`
+	TcfPolicyVersion      uint32                  `protobuf:"varint,9,opt,name=tcf_policy_version,json=tcfPolicyVersion,proto3" json:"tcf_policy_version,omitempty"`                // TCF 2.0 only
+	IsServiceSpecific     bool                    `protobuf:"varint,10,opt,name=is_service_specific,json=isServiceSpecific,proto3" json:"is_service_specific,omitempty"`            // TCF 2.0 only
+	UseNonStandardStacks  bool                    `protobuf:"varint,11,opt,name=use_non_standard_stacks,json=useNonStandardStacks,proto3" json:"use_non_standard_stacks,omitempty"` // TCF 2.0 only
+	PurposeOneTreatment   bool                    `protobuf:"varint,12,opt,name=purpose_one_treatment,json=purposeOneTreatment,proto3" json:"purpose_one_treatment,omitempty"`      // TCF 2.0 only
+	PublisherCc           string                  `protobuf:"bytes,13,opt,name=publisher_cc,json=publisherCc,proto3" json:"publisher_cc,omitempty"`                                 // TCF 2.0 only
+	Purposes              *LegalBases             `protobuf:"bytes,14,opt,name=purposes,proto3" json:"purposes,omitempty"`
+	SpecialFeatureOptIns  []uint32                `protobuf:"varint,15,rep,packed,name=special_feature_opt_ins,json=specialFeatureOptIns,proto3" json:"special_feature_opt_ins,omitempty"` // TCF 2.0 only
+	Vendors               *VendorSections         `protobuf:"bytes,16,opt,name=vendors,proto3" json:"vendors,omitempty"`
+	PublisherRestrictions []*PublisherRestriction `protobuf:"bytes,17,rep,name=publisher_restrictions,json=publisherRestrictions,proto3" json:"publisher_restrictions,omitempty"` // TCF 2.0 only
+	Segments              *Segments               `protobuf:"bytes,18,opt,name=segments,proto3" json:"segments,omitempty"`                                                        // TCF 2.0 only
+}
+
+func (x *TCFConsent) Reset() {
+	*x = TCFConsent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TCFConsent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TCFConsent) ProtoMessage() {}
+
+func (x *TCFConsent) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TCFConsent.ProtoReflect.Descriptor instead.
+func (*TCFConsent) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TCFConsent) GetTcfVersion() uint32 {
+	if x != nil {
+		return x.TcfVersion
+	}
+	return 0
+}
+
+func (x *TCFConsent) GetCreatedUnixMillis() int64 {
+	if x != nil {
+		return x.CreatedUnixMillis
+	}
+	return 0
+}
+
+func (x *TCFConsent) GetLastUpdatedUnixMillis() int64 {
+	if x != nil {
+		return x.LastUpdatedUnixMillis
+	}
+	return 0
+}
+
+func (x *TCFConsent) GetCmpId() uint32 {
+	if x != nil {
+		return x.CmpId
+	}
+	return 0
+}
+
+func (x *TCFConsent) GetCmpVersion() uint32 {
+	if x != nil {
+		return x.CmpVersion
+	}
+	return 0
+}
+
+func (x *TCFConsent) GetConsentScreen() uint32 {
+	if x != nil {
+		return x.ConsentScreen
+	}
+	return 0
+}
+
+func (x *TCFConsent) GetConsentLanguage() string {
+	if x != nil {
+		return x.ConsentLanguage
+	}
+	return ""
+}
+
+func (x *TCFConsent) GetVendorListVersion() uint32 {
+	if x != nil {
+		return x.VendorListVersion
+	}
+	return 0
+}
+
+func (x *TCFConsent) GetTcfPolicyVersion() uint32 {
+	if x != nil {
+		return x.TcfPolicyVersion
+	}
+	return 0
+}
+
+func (x *TCFConsent) GetIsServiceSpecific() bool {
+	if x != nil {
+		return x.IsServiceSpecific
+	}
+	return false
+}
+
+func (x *TCFConsent) GetUseNonStandardStacks() bool {
+	if x != nil {
+		return x.UseNonStandardStacks
+	}
+	return false
+}
+
+func (x *TCFConsent) GetPurposeOneTreatment() bool {
+	if x != nil {
+		return x.PurposeOneTreatment
+	}
+	return false
+}
+
+func (x *TCFConsent) GetPublisherCc() string {
+	if x != nil {
+		return x.PublisherCc
+	}
+	return ""
+}
+
+func (x *TCFConsent) GetPurposes() *LegalBases {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
+func (x *TCFConsent) GetSpecialFeatureOptIns() []uint32 {
+	if x != nil {
+		return x.SpecialFeatureOptIns
+	}
+	return nil
+}
+
+func (x *TCFConsent) GetVendors() *VendorSections {
+	if x != nil {
+		return x.Vendors
+	}
+	return nil
+}
+
+func (x *TCFConsent) GetPublisherRestrictions() []*PublisherRestriction {
+	if x != nil {
+		return x.PublisherRestrictions
+	}
+	return nil
+}
+
+func (x *TCFConsent) GetSegments() *Segments {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+// LegalBases contains the sorted IDs allowed for each legal basis. TCF 1.0 consents
+// don't have legitimate interests.
+type LegalBases struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Consents            []uint32 `protobuf:"varint,1,rep,packed,name=consents,proto3" json:"consents,omitempty"`
+	LegitimateInterests []uint32 `protobuf:"varint,2,rep,packed,name=legitimate_interests,json=legitimateInterests,proto3" json:"legitimate_interests,omitempty"`
+}
+
+func (x *LegalBases) Reset() {
+	*x = LegalBases{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LegalBases) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegalBases) ProtoMessage() {}
+
+func (x *LegalBases) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegalBases.ProtoReflect.Descriptor instead.
+func (*LegalBases) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LegalBases) GetConsents() []uint32 {
+	if x != nil {
+		return x.Consents
+	}
+	return nil
+}
+
+func (x *LegalBases) GetLegitimateInterests() []uint32 {
+	if x != nil {
+		return x.LegitimateInterests
+	}
+	return nil
+}
+
+// VendorList is a sorted list of vendors, with the way it was encoded.
+type VendorList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxVendorId     uint32   `protobuf:"varint,1,opt,name=max_vendor_id,json=maxVendorId,proto3" json:"max_vendor_id,omitempty"`
+	IsRangeEncoding bool     `protobuf:"varint,2,opt,name=is_range_encoding,json=isRangeEncoding,proto3" json:"is_range_encoding,omitempty"`
+	Ids             []uint32 `protobuf:"varint,3,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *VendorList) Reset() {
+	*x = VendorList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VendorList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VendorList) ProtoMessage() {}
+
+func (x *VendorList) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VendorList.ProtoReflect.Descriptor instead.
+func (*VendorList) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VendorList) GetMaxVendorId() uint32 {
+	if x != nil {
+		return x.MaxVendorId
+	}
+	return 0
+}
+
+func (x *VendorList) GetIsRangeEncoding() bool {
+	if x != nil {
+		return x.IsRangeEncoding
+	}
+	return false
+}
+
+func (x *VendorList) GetIds() []uint32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// VendorSections contains the vendors allowed for each legal basis.
+type VendorSections struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Consents            *VendorList `protobuf:"bytes,1,opt,name=consents,proto3" json:"consents,omitempty"`
+	LegitimateInterests *VendorList `protobuf:"bytes,2,opt,name=legitimate_interests,json=legitimateInterests,proto3" json:"legitimate_interests,omitempty"`
+}
+
+func (x *VendorSections) Reset() {
+	*x = VendorSections{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VendorSections) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VendorSections) ProtoMessage() {}
+
+func (x *VendorSections) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VendorSections.ProtoReflect.Descriptor instead.
+func (*VendorSections) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VendorSections) GetConsents() *VendorList {
+	if x != nil {
+		return x.Consents
+	}
+	return nil
+}
+
+func (x *VendorSections) GetLegitimateInterests() *VendorList {
+	if x != nil {
+		return x.LegitimateInterests
+	}
+	return nil
+}
+
+// PublisherRestriction is a publisher restriction of a purpose for some vendors.
+type PublisherRestriction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PurposeId       uint32   `protobuf:"varint,1,opt,name=purpose_id,json=purposeId,proto3" json:"purpose_id,omitempty"`
+	RestrictionType uint32   `protobuf:"varint,2,opt,name=restriction_type,json=restrictionType,proto3" json:"restriction_type,omitempty"`
+	VendorIds       []uint32 `protobuf:"varint,3,rep,packed,name=vendor_ids,json=vendorIds,proto3" json:"vendor_ids,omitempty"`
+}
+
+func (x *PublisherRestriction) Reset() {
+	*x = PublisherRestriction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublisherRestriction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublisherRestriction) ProtoMessage() {}
+
+func (x *PublisherRestriction) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublisherRestriction.ProtoReflect.Descriptor instead.
+func (*PublisherRestriction) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PublisherRestriction) GetPurposeId() uint32 {
+	if x != nil {
+		return x.PurposeId
+	}
+	return 0
+}
+
+func (x *PublisherRestriction) GetRestrictionType() uint32 {
+	if x != nil {
+		return x.RestrictionType
+	}
+	return 0
+}
+
+func (x *PublisherRestriction) GetVendorIds() []uint32 {
+	if x != nil {
+		return x.VendorIds
+	}
+	return nil
+}
+
+// Segments contains the segments following the core one, if present.
+type Segments struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DisclosedVendors *VendorList  `protobuf:"bytes,1,opt,name=disclosed_vendors,json=disclosedVendors,proto3" json:"disclosed_vendors,omitempty"`
+	AllowedVendors   *VendorList  `protobuf:"bytes,2,opt,name=allowed_vendors,json=allowedVendors,proto3" json:"allowed_vendors,omitempty"`
+	PublisherTc      *PublisherTC `protobuf:"bytes,3,opt,name=publisher_tc,json=publisherTc,proto3" json:"publisher_tc,omitempty"`
+}
+
+func (x *Segments) Reset() {
+	*x = Segments{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Segments) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Segments) ProtoMessage() {}
+
+func (x *Segments) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Segments.ProtoReflect.Descriptor instead.
+func (*Segments) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Segments) GetDisclosedVendors() *VendorList {
+	if x != nil {
+		return x.DisclosedVendors
+	}
+	return nil
+}
+
+func (x *Segments) GetAllowedVendors() *VendorList {
+	if x != nil {
+		return x.AllowedVendors
+	}
+	return nil
+}
+
+func (x *Segments) GetPublisherTc() *PublisherTC {
+	if x != nil {
+		return x.PublisherTc
+	}
+	return nil
+}
+
+// PublisherTC is the publisher purposes segment.
+type PublisherTC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Purposes          *LegalBases `protobuf:"bytes,1,opt,name=purposes,proto3" json:"purposes,omitempty"`
+	NumCustomPurposes uint32      `protobuf:"varint,2,opt,name=num_custom_purposes,json=numCustomPurposes,proto3" json:"num_custom_purposes,omitempty"`
+	CustomPurposes    *LegalBases `protobuf:"bytes,3,opt,name=custom_purposes,json=customPurposes,proto3" json:"custom_purposes,omitempty"`
+}
+
+func (x *PublisherTC) Reset() {
+	*x = PublisherTC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublisherTC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublisherTC) ProtoMessage() {}
+
+func (x *PublisherTC) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublisherTC.ProtoReflect.Descriptor instead.
+func (*PublisherTC) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PublisherTC) GetPurposes() *LegalBases {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
+func (x *PublisherTC) GetNumCustomPurposes() uint32 {
+	if x != nil {
+		return x.NumCustomPurposes
+	}
+	return 0
+}
+
+func (x *PublisherTC) GetCustomPurposes() *LegalBases {
+	if x != nil {
+		return x.CustomPurposes
+	}
+	return nil
+}
+
+// USPrivacy is a decoded US Privacy (CCPA) string. The flags are one of `Y`, `N` or `-`.
+type USPrivacy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version     uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Notice      string `protobuf:"bytes,2,opt,name=notice,proto3" json:"notice,omitempty"`
+	OptOutSale  string `protobuf:"bytes,3,opt,name=opt_out_sale,json=optOutSale,proto3" json:"opt_out_sale,omitempty"`
+	LspaCovered string `protobuf:"bytes,4,opt,name=lspa_covered,json=lspaCovered,proto3" json:"lspa_covered,omitempty"`
+}
+
+func (x *USPrivacy) Reset() {
+	*x = USPrivacy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *USPrivacy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*USPrivacy) ProtoMessage() {}
+
+func (x *USPrivacy) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use USPrivacy.ProtoReflect.Descriptor instead.
+func (*USPrivacy) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *USPrivacy) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *USPrivacy) GetNotice() string {
+	if x != nil {
+		return x.Notice
+	}
+	return ""
+}
+
+func (x *USPrivacy) GetOptOutSale() string {
+	if x != nil {
+		return x.OptOutSale
+	}
+	return ""
+}
+
+func (x *USPrivacy) GetLspaCovered() string {
+	if x != nil {
+		return x.LspaCovered
+	}
+	return ""
+}
+
+// AdditionalConsent is a decoded Google Additional Consent string.
+type AdditionalConsent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version              uint32   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	ConsentedProviderIds []uint32 `protobuf:"varint,2,rep,packed,name=consented_provider_ids,json=consentedProviderIds,proto3" json:"consented_provider_ids,omitempty"`
+	DisclosedProviderIds []uint32 `protobuf:"varint,3,rep,packed,name=disclosed_provider_ids,json=disclosedProviderIds,proto3" json:"disclosed_provider_ids,omitempty"`
+}
+
+func (x *AdditionalConsent) Reset() {
+	*x = AdditionalConsent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdditionalConsent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdditionalConsent) ProtoMessage() {}
+
+func (x *AdditionalConsent) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdditionalConsent.ProtoReflect.Descriptor instead.
+func (*AdditionalConsent) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AdditionalConsent) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AdditionalConsent) GetConsentedProviderIds() []uint32 {
+	if x != nil {
+		return x.ConsentedProviderIds
+	}
+	return nil
+}
+
+func (x *AdditionalConsent) GetDisclosedProviderIds() []uint32 {
+	if x != nil {
+		return x.DisclosedProviderIds
+	}
+	return nil
+}
+
+// TCFCanadaConsent is a decoded TCF Canada (tcfcav1) section of a GPP string, where
+// consent is either express or implied. It follows the JSON representation of gpp.TCFCanada.
+type TCFCanadaConsent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SectionVersion uint32 `protobuf:"varint,1,opt,name=section_version,json=sectionVersion,proto3" json:"section_version,omitempty"`
+	// Timestamps as milliseconds since the Unix epoch, with decisecond precision.
+	CreatedUnixMillis             int64                    `protobuf:"varint,2,opt,name=created_unix_millis,json=createdUnixMillis,proto3" json:"created_unix_millis,omitempty"`
+	LastUpdatedUnixMillis         int64                    `protobuf:"varint,3,opt,name=last_updated_unix_millis,json=lastUpdatedUnixMillis,proto3" json:"last_updated_unix_millis,omitempty"`
+	CmpId                         uint32                   `protobuf:"varint,4,opt,name=cmp_id,json=cmpId,proto3" json:"cmp_id,omitempty"`
+	CmpVersion                    uint32                   `protobuf:"varint,5,opt,name=cmp_version,json=cmpVersion,proto3" json:"cmp_version,omitempty"`
+	ConsentScreen                 uint32                   `protobuf:"varint,6,opt,name=consent_screen,json=consentScreen,proto3" json:"consent_screen,omitempty"`
+	ConsentLanguage               string                   `protobuf:"bytes,7,opt,name=consent_language,json=consentLanguage,proto3" json:"consent_language,omitempty"`
+	VendorListVersion             uint32                   `protobuf:"varint,8,opt,name=vendor_list_version,json=vendorListVersion,proto3" json:"vendor_list_version,omitempty"`
+	TcfPolicyVersion              uint32                   `protobuf:"varint,9,opt,name=tcf_policy_version,json=tcfPolicyVersion,proto3" json:"tcf_policy_version,omitempty"`
+	UseNonStandardStacks          bool                     `protobuf:"varint,10,opt,name=use_non_standard_stacks,json=useNonStandardStacks,proto3" json:"use_non_standard_stacks,omitempty"`
+	Purposes                      *TCFCanadaLegalBases     `protobuf:"bytes,11,opt,name=purposes,proto3" json:"purposes,omitempty"`
+	SpecialFeatureExpressConsents []uint32                 `protobuf:"varint,12,rep,packed,name=special_feature_express_consents,json=specialFeatureExpressConsents,proto3" json:"special_feature_express_consents,omitempty"`
+	Vendors                       *TCFCanadaVendorSections `protobuf:"bytes,13,opt,name=vendors,proto3" json:"vendors,omitempty"`
+	PublisherRestrictions         []*PublisherRestriction  `protobuf:"bytes,14,rep,name=publisher_restrictions,json=publisherRestrictions,proto3" json:"publisher_restrictions,omitempty"`
+	PublisherTc                   *TCFCanadaPublisherTC    `protobuf:"bytes,15,opt,name=publisher_tc,json=publisherTc,proto3" json:"publisher_tc,omitempty"`
+}
+
+func (x *TCFCanadaConsent) Reset() {
+	*x = TCFCanadaConsent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TCFCanadaConsent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TCFCanadaConsent) ProtoMessage() {}
+
+func (x *TCFCanadaConsent) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TCFCanadaConsent.ProtoReflect.Descriptor instead.
+func (*TCFCanadaConsent) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TCFCanadaConsent) GetSectionVersion() uint32 {
+	if x != nil {
+		return x.SectionVersion
+	}
+	return 0
+}
+
+func (x *TCFCanadaConsent) GetCreatedUnixMillis() int64 {
+	if x != nil {
+		return x.CreatedUnixMillis
+	}
+	return 0
+}
+
+func (x *TCFCanadaConsent) GetLastUpdatedUnixMillis() int64 {
+	if x != nil {
+		return x.LastUpdatedUnixMillis
+	}
+	return 0
+}
+
+func (x *TCFCanadaConsent) GetCmpId() uint32 {
+	if x != nil {
+		return x.CmpId
+	}
+	return 0
+}
+
+func (x *TCFCanadaConsent) GetCmpVersion() uint32 {
+	if x != nil {
+		return x.CmpVersion
+	}
+	return 0
+}
+
+func (x *TCFCanadaConsent) GetConsentScreen() uint32 {
+	if x != nil {
+		return x.ConsentScreen
+	}
+	return 0
+}
+
+func (x *TCFCanadaConsent) GetConsentLanguage() string {
+	if x != nil {
+		return x.ConsentLanguage
+	}
+	return ""
+}
+
+func (x *TCFCanadaConsent) GetVendorListVersion() uint32 {
+	if x != nil {
+		return x.VendorListVersion
+	}
+	return 0
+}
+
+func (x *TCFCanadaConsent) GetTcfPolicyVersion() uint32 {
+	if x != nil {
+		return x.TcfPolicyVersion
+	}
+	return 0
+}
+
+func (x *TCFCanadaConsent) GetUseNonStandardStacks() bool {
+	if x != nil {
+		return x.UseNonStandardStacks
+	}
+	return false
+}
+
+func (x *TCFCanadaConsent) GetPurposes() *TCFCanadaLegalBases {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
+func (x *TCFCanadaConsent) GetSpecialFeatureExpressConsents() []uint32 {
+	if x != nil {
+		return x.SpecialFeatureExpressConsents
+	}
+	return nil
+}
+
+func (x *TCFCanadaConsent) GetVendors() *TCFCanadaVendorSections {
+	if x != nil {
+		return x.Vendors
+	}
+	return nil
+}
+
+func (x *TCFCanadaConsent) GetPublisherRestrictions() []*PublisherRestriction {
+	if x != nil {
+		return x.PublisherRestrictions
+	}
+	return nil
+}
+
+func (x *TCFCanadaConsent) GetPublisherTc() *TCFCanadaPublisherTC {
+	if x != nil {
+		return x.PublisherTc
+	}
+	return nil
+}
+
+// TCFCanadaLegalBases contains the sorted IDs allowed for each legal basis of TCF Canada.
+type TCFCanadaLegalBases struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExpressConsents []uint32 `protobuf:"varint,1,rep,packed,name=express_consents,json=expressConsents,proto3" json:"express_consents,omitempty"`
+	ImpliedConsents []uint32 `protobuf:"varint,2,rep,packed,name=implied_consents,json=impliedConsents,proto3" json:"implied_consents,omitempty"`
+}
+
+func (x *TCFCanadaLegalBases) Reset() {
+	*x = TCFCanadaLegalBases{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TCFCanadaLegalBases) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TCFCanadaLegalBases) ProtoMessage() {}
+
+func (x *TCFCanadaLegalBases) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TCFCanadaLegalBases.ProtoReflect.Descriptor instead.
+func (*TCFCanadaLegalBases) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TCFCanadaLegalBases) GetExpressConsents() []uint32 {
+	if x != nil {
+		return x.ExpressConsents
+	}
+	return nil
+}
+
+func (x *TCFCanadaLegalBases) GetImpliedConsents() []uint32 {
+	if x != nil {
+		return x.ImpliedConsents
+	}
+	return nil
+}
+
+// TCFCanadaVendorSections contains the vendors allowed for each legal basis of TCF Canada.
+type TCFCanadaVendorSections struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExpressConsents *VendorList `protobuf:"bytes,1,opt,name=express_consents,json=expressConsents,proto3" json:"express_consents,omitempty"`
+	ImpliedConsents *VendorList `protobuf:"bytes,2,opt,name=implied_consents,json=impliedConsents,proto3" json:"implied_consents,omitempty"`
+}
+
+func (x *TCFCanadaVendorSections) Reset() {
+	*x = TCFCanadaVendorSections{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TCFCanadaVendorSections) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TCFCanadaVendorSections) ProtoMessage() {}
+
+func (x *TCFCanadaVendorSections) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TCFCanadaVendorSections.ProtoReflect.Descriptor instead.
+func (*TCFCanadaVendorSections) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TCFCanadaVendorSections) GetExpressConsents() *VendorList {
+	if x != nil {
+		return x.ExpressConsents
+	}
+	return nil
+}
+
+func (x *TCFCanadaVendorSections) GetImpliedConsents() *VendorList {
+	if x != nil {
+		return x.ImpliedConsents
+	}
+	return nil
+}
+
+// TCFCanadaPublisherTC is the publisher purposes segment of TCF Canada.
+type TCFCanadaPublisherTC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Purposes          *TCFCanadaLegalBases `protobuf:"bytes,1,opt,name=purposes,proto3" json:"purposes,omitempty"`
+	NumCustomPurposes uint32               `protobuf:"varint,2,opt,name=num_custom_purposes,json=numCustomPurposes,proto3" json:"num_custom_purposes,omitempty"`
+	CustomPurposes    *TCFCanadaLegalBases `protobuf:"bytes,3,opt,name=custom_purposes,json=customPurposes,proto3" json:"custom_purposes,omitempty"`
+}
+
+func (x *TCFCanadaPublisherTC) Reset() {
+	*x = TCFCanadaPublisherTC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TCFCanadaPublisherTC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TCFCanadaPublisherTC) ProtoMessage() {}
+
+func (x *TCFCanadaPublisherTC) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TCFCanadaPublisherTC.ProtoReflect.Descriptor instead.
+func (*TCFCanadaPublisherTC) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TCFCanadaPublisherTC) GetPurposes() *TCFCanadaLegalBases {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
+func (x *TCFCanadaPublisherTC) GetNumCustomPurposes() uint32 {
+	if x != nil {
+		return x.NumCustomPurposes
+	}
+	return 0
+}
+
+func (x *TCFCanadaPublisherTC) GetCustomPurposes() *TCFCanadaLegalBases {
+	if x != nil {
+		return x.CustomPurposes
+	}
+	return nil
+}
+
+// USSection is a decoded US National (usnat) or US state section of a GPP string. The
+// fields have the values encoded in the section, 0 being not applicable, with the
+// sensitive data and known child fields sorted by category.
+type USSection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SectionId                           uint32   `protobuf:"varint,1,opt,name=section_id,json=sectionId,proto3" json:"section_id,omitempty"`
+	Version                             uint32   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	SharingNotice                       uint32   `protobuf:"varint,3,opt,name=sharing_notice,json=sharingNotice,proto3" json:"sharing_notice,omitempty"`
+	SaleOptOutNotice                    uint32   `protobuf:"varint,4,opt,name=sale_opt_out_notice,json=saleOptOutNotice,proto3" json:"sale_opt_out_notice,omitempty"`
+	SharingOptOutNotice                 uint32   `protobuf:"varint,5,opt,name=sharing_opt_out_notice,json=sharingOptOutNotice,proto3" json:"sharing_opt_out_notice,omitempty"`
+	TargetedAdvertisingOptOutNotice     uint32   `protobuf:"varint,6,opt,name=targeted_advertising_opt_out_notice,json=targetedAdvertisingOptOutNotice,proto3" json:"targeted_advertising_opt_out_notice,omitempty"`
+	SensitiveDataProcessingOptOutNotice uint32   `protobuf:"varint,7,opt,name=sensitive_data_processing_opt_out_notice,json=sensitiveDataProcessingOptOutNotice,proto3" json:"sensitive_data_processing_opt_out_notice,omitempty"`
+	SensitiveDataLimitUseNotice         uint32   `protobuf:"varint,8,opt,name=sensitive_data_limit_use_notice,json=sensitiveDataLimitUseNotice,proto3" json:"sensitive_data_limit_use_notice,omitempty"`
+	SaleOptOut                          uint32   `protobuf:"varint,9,opt,name=sale_opt_out,json=saleOptOut,proto3" json:"sale_opt_out,omitempty"`
+	SharingOptOut                       uint32   `protobuf:"varint,10,opt,name=sharing_opt_out,json=sharingOptOut,proto3" json:"sharing_opt_out,omitempty"`
+	TargetedAdvertisingOptOut           uint32   `protobuf:"varint,11,opt,name=targeted_advertising_opt_out,json=targetedAdvertisingOptOut,proto3" json:"targeted_advertising_opt_out,omitempty"`
+	SensitiveDataProcessingConsents     []uint32 `protobuf:"varint,12,rep,packed,name=sensitive_data_processing_consents,json=sensitiveDataProcessingConsents,proto3" json:"sensitive_data_processing_consents,omitempty"` // consent model sections only
+	SensitiveDataProcessingOptOuts      []uint32 `protobuf:"varint,13,rep,packed,name=sensitive_data_processing_opt_outs,json=sensitiveDataProcessingOptOuts,proto3" json:"sensitive_data_processing_opt_outs,omitempty"`  // opt-out model sections only
+	KnownChildSensitiveDataConsents     []uint32 `protobuf:"varint,14,rep,packed,name=known_child_sensitive_data_consents,json=knownChildSensitiveDataConsents,proto3" json:"known_child_sensitive_data_consents,omitempty"`
+	PersonalDataConsents                uint32   `protobuf:"varint,15,opt,name=personal_data_consents,json=personalDataConsents,proto3" json:"personal_data_consents,omitempty"`
+	MspaCoveredTransaction              uint32   `protobuf:"varint,16,opt,name=mspa_covered_transaction,json=mspaCoveredTransaction,proto3" json:"mspa_covered_transaction,omitempty"`
+	MspaOptOutOptionMode                uint32   `protobuf:"varint,17,opt,name=mspa_opt_out_option_mode,json=mspaOptOutOptionMode,proto3" json:"mspa_opt_out_option_mode,omitempty"`
+	MspaServiceProviderMode             uint32   `protobuf:"varint,18,opt,name=mspa_service_provider_mode,json=mspaServiceProviderMode,proto3" json:"mspa_service_provider_mode,omitempty"`
+	Gpc                                 bool     `protobuf:"varint,19,opt,name=gpc,proto3" json:"gpc,omitempty"`
+}
+
+func (x *USSection) Reset() {
+	*x = USSection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consent_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *USSection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*USSection) ProtoMessage() {}
+
+func (x *USSection) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use USSection.ProtoReflect.Descriptor instead.
+func (*USSection) Descriptor() ([]byte, []int) {
+	return file_consent_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *USSection) GetSectionId() uint32 {
+	if x != nil {
+		return x.SectionId
+	}
+	return 0
+}
+
+func (x *USSection) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *USSection) GetSharingNotice() uint32 {
+	if x != nil {
+		return x.SharingNotice
+	}
+	return 0
+}
+
+func (x *USSection) GetSaleOptOutNotice() uint32 {
+	if x != nil {
+		return x.SaleOptOutNotice
+	}
+	return 0
+}
+
+func (x *USSection) GetSharingOptOutNotice() uint32 {
+	if x != nil {
+		return x.SharingOptOutNotice
+	}
+	return 0
+}
+
+func (x *USSection) GetTargetedAdvertisingOptOutNotice() uint32 {
+	if x != nil {
+		return x.TargetedAdvertisingOptOutNotice
+	}
+	return 0
+}
+
+func (x *USSection) GetSensitiveDataProcessingOptOutNotice() uint32 {
+	if x != nil {
+		return x.SensitiveDataProcessingOptOutNotice
+	}
+	return 0
+}
+
+func (x *USSection) GetSensitiveDataLimitUseNotice() uint32 {
+	if x != nil {
+		return x.SensitiveDataLimitUseNotice
+	}
+	return 0
+}
+
+func (x *USSection) GetSaleOptOut() uint32 {
+	if x != nil {
+		return x.SaleOptOut
+	}
+	return 0
+}
+
+func (x *USSection) GetSharingOptOut() uint32 {
+	if x != nil {
+		return x.SharingOptOut
+	}
+	return 0
+}
+
+func (x *USSection) GetTargetedAdvertisingOptOut() uint32 {
+	if x != nil {
+		return x.TargetedAdvertisingOptOut
+	}
+	return 0
+}
+
+func (x *USSection) GetSensitiveDataProcessingConsents() []uint32 {
+	if x != nil {
+		return x.SensitiveDataProcessingConsents
+	}
+	return nil
+}
+
+func (x *USSection) GetSensitiveDataProcessingOptOuts() []uint32 {
+	if x != nil {
+		return x.SensitiveDataProcessingOptOuts
+	}
+	return nil
+}
+
+func (x *USSection) GetKnownChildSensitiveDataConsents() []uint32 {
+	if x != nil {
+		return x.KnownChildSensitiveDataConsents
+	}
+	return nil
+}
+
+func (x *USSection) GetPersonalDataConsents() uint32 {
+	if x != nil {
+		return x.PersonalDataConsents
+	}
+	return 0
+}
+
+func (x *USSection) GetMspaCoveredTransaction() uint32 {
+	if x != nil {
+		return x.MspaCoveredTransaction
+	}
+	return 0
+}
+
+func (x *USSection) GetMspaOptOutOptionMode() uint32 {
+	if x != nil {
+		return x.MspaOptOutOptionMode
+	}
+	return 0
+}
+
+func (x *USSection) GetMspaServiceProviderMode() uint32 {
+	if x != nil {
+		return x.MspaServiceProviderMode
+	}
+	return 0
+}
+
+func (x *USSection) GetGpc() bool {
+	if x != nil {
+		return x.Gpc
+	}
+	return false
+}
+
+var File_consent_proto protoreflect.FileDescriptor
+
+var file_consent_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x16, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61,
+	0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x22, 0xef, 0x02, 0x0a, 0x0e, 0x50, 0x72, 0x69, 0x76,
+	0x61, 0x63, 0x79, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x73, 0x12, 0x34, 0x0a, 0x03, 0x74, 0x63,
+	0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64,
+	0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x43, 0x46, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x52, 0x03, 0x74, 0x63, 0x66,
+	0x12, 0x40, 0x0a, 0x0a, 0x75, 0x73, 0x5f, 0x70, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65,
+	0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x53,
+	0x50, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x52, 0x09, 0x75, 0x73, 0x50, 0x72, 0x69, 0x76, 0x61,
+	0x63, 0x79, 0x12, 0x58, 0x0a, 0x12, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61,
+	0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x52, 0x11, 0x61, 0x64, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x47, 0x0a, 0x0a,
+	0x74, 0x63, 0x66, 0x5f, 0x63, 0x61, 0x6e, 0x61, 0x64, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e,
+	0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x43, 0x46, 0x43, 0x61, 0x6e,
+	0x61, 0x64, 0x61, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x74, 0x63, 0x66, 0x43,
+	0x61, 0x6e, 0x61, 0x64, 0x61, 0x12, 0x42, 0x0a, 0x0b, 0x75, 0x73, 0x5f, 0x73, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68, 0x79, 0x62,
+	0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66,
+	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x53, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x75,
+	0x73, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x98, 0x07, 0x0a, 0x0a, 0x54, 0x43,
+	0x46, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x63, 0x66, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74,
+	0x63, 0x66, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x55,
+	0x6e, 0x69, 0x78, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x37, 0x0a, 0x18, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6d,
+	0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x6c, 0x61, 0x73,
+	0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x55, 0x6e, 0x69, 0x78, 0x4d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x63, 0x6d, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x63, 0x6d, 0x70, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6d, 0x70,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x63, 0x6d, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f,
+	0x6e, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x53, 0x63, 0x72, 0x65, 0x65,
+	0x6e, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6e,
+	0x73, 0x65, 0x6e, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x13,
+	0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x76, 0x65, 0x6e, 0x64, 0x6f,
+	0x72, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x12,
+	0x74, 0x63, 0x66, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x74, 0x63, 0x66, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x69, 0x73,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
+	0x63, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x69, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x12, 0x35, 0x0a, 0x17, 0x75, 0x73,
+	0x65, 0x5f, 0x6e, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x61, 0x72, 0x64, 0x5f, 0x73,
+	0x74, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x75, 0x73, 0x65,
+	0x4e, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x63, 0x6b,
+	0x73, 0x12, 0x32, 0x0a, 0x15, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x5f, 0x6f, 0x6e, 0x65,
+	0x5f, 0x74, 0x72, 0x65, 0x61, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x13, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x4f, 0x6e, 0x65, 0x54, 0x72, 0x65, 0x61,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
+	0x65, 0x72, 0x5f, 0x63, 0x63, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x43, 0x63, 0x12, 0x3e, 0x0a, 0x08, 0x70, 0x75, 0x72, 0x70,
+	0x6f, 0x73, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x79, 0x62,
+	0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x6c, 0x42, 0x61, 0x73, 0x65, 0x73, 0x52, 0x08,
+	0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x17, 0x73, 0x70, 0x65, 0x63,
+	0x69, 0x61, 0x6c, 0x5f, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x5f,
+	0x69, 0x6e, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x14, 0x73, 0x70, 0x65, 0x63, 0x69,
+	0x61, 0x6c, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4f, 0x70, 0x74, 0x49, 0x6e, 0x73, 0x12,
+	0x40, 0x0a, 0x07, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e,
+	0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72,
+	0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72,
+	0x73, 0x12, 0x63, 0x0a, 0x16, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x5f, 0x72,
+	0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2c, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79,
+	0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x72, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x15, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3c, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69,
+	0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x22, 0x5b, 0x0a, 0x0a, 0x4c, 0x65, 0x67, 0x61, 0x6c, 0x42, 0x61, 0x73,
+	0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0d, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x31,
+	0x0a, 0x14, 0x6c, 0x65, 0x67, 0x69, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x13, 0x6c, 0x65,
+	0x67, 0x69, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74,
+	0x73, 0x22, 0x6e, 0x0a, 0x0a, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x56, 0x65, 0x6e, 0x64, 0x6f,
+	0x72, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x69, 0x73, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f,
+	0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f,
+	0x69, 0x73, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12,
+	0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x03, 0x69, 0x64,
+	0x73, 0x22, 0xa7, 0x01, 0x0a, 0x0e, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x53, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3e, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74,
+	0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x73,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x55, 0x0a, 0x14, 0x6c, 0x65, 0x67, 0x69, 0x74, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72,
+	0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x6e, 0x64,
+	0x6f, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x13, 0x6c, 0x65, 0x67, 0x69, 0x74, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x73, 0x22, 0x7f, 0x0a, 0x14, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65,
+	0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65,
+	0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0d, 0x52, 0x09, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x49, 0x64, 0x73, 0x22, 0xf0, 0x01, 0x0a,
+	0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x4f, 0x0a, 0x11, 0x64, 0x69, 0x73,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65,
+	0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65,
+	0x6e, 0x64, 0x6f, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x10, 0x64, 0x69, 0x73, 0x63, 0x6c, 0x6f,
+	0x73, 0x65, 0x64, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x12, 0x4b, 0x0a, 0x0f, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f,
+	0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x6e,
+	0x64, 0x6f, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64,
+	0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x12, 0x46, 0x0a, 0x0c, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x72, 0x5f, 0x74, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62,
+	0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72,
+	0x54, 0x43, 0x52, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x54, 0x63, 0x22,
+	0xca, 0x01, 0x0a, 0x0b, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x54, 0x43, 0x12,
+	0x3e, 0x0a, 0x08, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79,
+	0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x6c,
+	0x42, 0x61, 0x73, 0x65, 0x73, 0x52, 0x08, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x73, 0x12,
+	0x2e, 0x0a, 0x13, 0x6e, 0x75, 0x6d, 0x5f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x70, 0x75,
+	0x72, 0x70, 0x6f, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6e, 0x75,
+	0x6d, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x50, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x73, 0x12,
+	0x4b, 0x0a, 0x0f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69,
+	0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x6c, 0x42, 0x61, 0x73, 0x65, 0x73, 0x52, 0x0e, 0x63, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x50, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x73, 0x22, 0x82, 0x01, 0x0a,
+	0x09, 0x55, 0x53, 0x50, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0c,
+	0x6f, 0x70, 0x74, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x61, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6f, 0x70, 0x74, 0x4f, 0x75, 0x74, 0x53, 0x61, 0x6c, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x6c, 0x73, 0x70, 0x61, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x73, 0x70, 0x61, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x65,
+	0x64, 0x22, 0x99, 0x01, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x34, 0x0a, 0x16, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x70,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0d, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x64, 0x69, 0x73, 0x63, 0x6c,
+	0x6f, 0x73, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x14, 0x64, 0x69, 0x73, 0x63, 0x6c, 0x6f, 0x73,
+	0x65, 0x64, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0xd6, 0x06,
+	0x0a, 0x10, 0x54, 0x43, 0x46, 0x43, 0x61, 0x6e, 0x61, 0x64, 0x61, 0x43, 0x6f, 0x6e, 0x73, 0x65,
+	0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x73, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x55, 0x6e, 0x69, 0x78, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x37, 0x0a, 0x18, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x6e, 0x69, 0x78,
+	0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x6c,
+	0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x55, 0x6e, 0x69, 0x78, 0x4d, 0x69,
+	0x6c, 0x6c, 0x69, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x63, 0x6d, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6d, 0x70, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63,
+	0x6d, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0a, 0x63, 0x6d, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e,
+	0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x53, 0x63, 0x72,
+	0x65, 0x65, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63,
+	0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x2e,
+	0x0a, 0x13, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x76, 0x65, 0x6e,
+	0x64, 0x6f, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c,
+	0x0a, 0x12, 0x74, 0x63, 0x66, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x74, 0x63, 0x66, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x17,
+	0x75, 0x73, 0x65, 0x5f, 0x6e, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x61, 0x72, 0x64,
+	0x5f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x75,
+	0x73, 0x65, 0x4e, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61,
+	0x63, 0x6b, 0x73, 0x12, 0x47, 0x0a, 0x08, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x73, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68,
+	0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x43, 0x46, 0x43, 0x61, 0x6e, 0x61, 0x64, 0x61, 0x4c, 0x65, 0x67, 0x61, 0x6c, 0x42, 0x61, 0x73,
+	0x65, 0x73, 0x52, 0x08, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x73, 0x12, 0x47, 0x0a, 0x20,
+	0x73, 0x70, 0x65, 0x63, 0x69, 0x61, 0x6c, 0x5f, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f,
+	0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x0c, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x1d, 0x73, 0x70, 0x65, 0x63, 0x69, 0x61, 0x6c, 0x46,
+	0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x6e,
+	0x73, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x49, 0x0a, 0x07, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74,
+	0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x43, 0x46, 0x43, 0x61, 0x6e, 0x61, 0x64, 0x61, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x53,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73,
+	0x12, 0x63, 0x0a, 0x16, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x5f, 0x72, 0x65,
+	0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2c, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e,
+	0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x65, 0x72, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x15,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x4f, 0x0a, 0x0c, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
+	0x65, 0x72, 0x5f, 0x74, 0x63, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x68, 0x79,
+	0x62, 0x72, 0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63,
+	0x66, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x43, 0x46, 0x43, 0x61, 0x6e, 0x61, 0x64, 0x61, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x54, 0x43, 0x52, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x72, 0x54, 0x63, 0x22, 0x6b, 0x0a, 0x13, 0x54, 0x43, 0x46, 0x43, 0x61, 0x6e,
+	0x61, 0x64, 0x61, 0x4c, 0x65, 0x67, 0x61, 0x6c, 0x42, 0x61, 0x73, 0x65, 0x73, 0x12, 0x29, 0x0a,
+	0x10, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x69, 0x6d, 0x70, 0x6c,
+	0x69, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0d, 0x52, 0x0f, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65,
+	0x6e, 0x74, 0x73, 0x22, 0xb7, 0x01, 0x0a, 0x17, 0x54, 0x43, 0x46, 0x43, 0x61, 0x6e, 0x61, 0x64,
+	0x61, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x4d, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x79, 0x62, 0x72,
+	0x69, 0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x0f, 0x65,
+	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x4d,
+	0x0a, 0x10, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69,
+	0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x0f, 0x69, 0x6d,
+	0x70, 0x6c, 0x69, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xe5, 0x01,
+	0x0a, 0x14, 0x54, 0x43, 0x46, 0x43, 0x61, 0x6e, 0x61, 0x64, 0x61, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x72, 0x54, 0x43, 0x12, 0x47, 0x0a, 0x08, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69,
+	0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x43, 0x46, 0x43, 0x61, 0x6e, 0x61, 0x64, 0x61, 0x4c, 0x65, 0x67, 0x61, 0x6c,
+	0x42, 0x61, 0x73, 0x65, 0x73, 0x52, 0x08, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x73, 0x12,
+	0x2e, 0x0a, 0x13, 0x6e, 0x75, 0x6d, 0x5f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x70, 0x75,
+	0x72, 0x70, 0x6f, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6e, 0x75,
+	0x6d, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x50, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x73, 0x12,
+	0x54, 0x0a, 0x0f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x68, 0x79, 0x62, 0x72, 0x69,
+	0x64, 0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2e, 0x69, 0x61, 0x62, 0x74, 0x63, 0x66, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x43, 0x46, 0x43, 0x61, 0x6e, 0x61, 0x64, 0x61, 0x4c, 0x65, 0x67, 0x61, 0x6c,
+	0x42, 0x61, 0x73, 0x65, 0x73, 0x52, 0x0e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x50, 0x75, 0x72,
+	0x70, 0x6f, 0x73, 0x65, 0x73, 0x22, 0xa3, 0x08, 0x0a, 0x09, 0x55, 0x53, 0x53, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e,
+	0x73, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x73, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x4e, 0x6f, 0x74,
+	0x69, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x13, 0x73, 0x61, 0x6c, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x5f,
+	0x6f, 0x75, 0x74, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x10, 0x73, 0x61, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x4f, 0x75, 0x74, 0x4e, 0x6f, 0x74, 0x69,
+	0x63, 0x65, 0x12, 0x33, 0x0a, 0x16, 0x73, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x70,
+	0x74, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x13, 0x73, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x4f, 0x70, 0x74, 0x4f, 0x75,
+	0x74, 0x4e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x12, 0x4c, 0x0a, 0x23, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x69, 0x73, 0x69, 0x6e, 0x67, 0x5f,
+	0x6f, 0x70, 0x74, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x1f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x65, 0x64, 0x41, 0x64,
+	0x76, 0x65, 0x72, 0x74, 0x69, 0x73, 0x69, 0x6e, 0x67, 0x4f, 0x70, 0x74, 0x4f, 0x75, 0x74, 0x4e,
+	0x6f, 0x74, 0x69, 0x63, 0x65, 0x12, 0x55, 0x0a, 0x28, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69,
+	0x6e, 0x67, 0x5f, 0x6f, 0x70, 0x74, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x63,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x23, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67,
+	0x4f, 0x70, 0x74, 0x4f, 0x75, 0x74, 0x4e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x12, 0x44, 0x0a, 0x1f,
+	0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1b, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x55, 0x73, 0x65, 0x4e, 0x6f, 0x74, 0x69,
+	0x63, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x61, 0x6c, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x5f, 0x6f,
+	0x75, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x61, 0x6c, 0x65, 0x4f, 0x70,
+	0x74, 0x4f, 0x75, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x5f,
+	0x6f, 0x70, 0x74, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x73,
+	0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x4f, 0x70, 0x74, 0x4f, 0x75, 0x74, 0x12, 0x3f, 0x0a, 0x1c,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x69,
+	0x73, 0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x70, 0x74, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x19, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x65, 0x64, 0x41, 0x64, 0x76, 0x65,
+	0x72, 0x74, 0x69, 0x73, 0x69, 0x6e, 0x67, 0x4f, 0x70, 0x74, 0x4f, 0x75, 0x74, 0x12, 0x4b, 0x0a,
+	0x22, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f,
+	0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x1f, 0x73, 0x65, 0x6e, 0x73, 0x69,
+	0x74, 0x69, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69,
+	0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x4a, 0x0a, 0x22, 0x73, 0x65,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x70, 0x74, 0x5f, 0x6f, 0x75, 0x74, 0x73,
+	0x18, 0x0d, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x1e, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x4f,
+	0x70, 0x74, 0x4f, 0x75, 0x74, 0x73, 0x12, 0x4c, 0x0a, 0x23, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f,
+	0x63, 0x68, 0x69, 0x6c, 0x64, 0x5f, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f,
+	0x64, 0x61, 0x74, 0x61, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0e, 0x20,
+	0x03, 0x28, 0x0d, 0x52, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x53,
+	0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x73,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c,
+	0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c, 0x44, 0x61,
+	0x74, 0x61, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x6d, 0x73,
+	0x70, 0x61, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x6d, 0x73,
+	0x70, 0x61, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x18, 0x6d, 0x73, 0x70, 0x61, 0x5f, 0x6f, 0x70, 0x74,
+	0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x6d, 0x73, 0x70, 0x61, 0x4f, 0x70, 0x74, 0x4f,
+	0x75, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x3b, 0x0a, 0x1a,
+	0x6d, 0x73, 0x70, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x17, 0x6d, 0x73, 0x70, 0x61, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x70, 0x63,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x67, 0x70, 0x63, 0x42, 0x2b, 0x5a, 0x29, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64,
+	0x74, 0x68, 0x65, 0x6f, 0x72, 0x79, 0x2f, 0x69, 0x61, 0x62, 0x2d, 0x74, 0x63, 0x66, 0x2f, 0x63,
+	0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_consent_proto_rawDescOnce sync.Once
+	file_consent_proto_rawDescData = file_consent_proto_rawDesc
+)
+
+func file_consent_proto_rawDescGZIP() []byte {
+	file_consent_proto_rawDescOnce.Do(func() {
+		file_consent_proto_rawDescData = protoimpl.X.CompressGZIP(file_consent_proto_rawDescData)
+	})
+	return file_consent_proto_rawDescData
+}
+
+var file_consent_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_consent_proto_goTypes = []any{
+	(*PrivacySignals)(nil),          // 0: hybridtheory.iabtcf.v1.PrivacySignals
+	(*TCFConsent)(nil),              // 1: hybridtheory.iabtcf.v1.TCFConsent
+	(*LegalBases)(nil),              // 2: hybridtheory.iabtcf.v1.LegalBases
+	(*VendorList)(nil),              // 3: hybridtheory.iabtcf.v1.VendorList
+	(*VendorSections)(nil),          // 4: hybridtheory.iabtcf.v1.VendorSections
+	(*PublisherRestriction)(nil),    // 5: hybridtheory.iabtcf.v1.PublisherRestriction
+	(*Segments)(nil),                // 6: hybridtheory.iabtcf.v1.Segments
+	(*PublisherTC)(nil),             // 7: hybridtheory.iabtcf.v1.PublisherTC
+	(*USPrivacy)(nil),               // 8: hybridtheory.iabtcf.v1.USPrivacy
+	(*AdditionalConsent)(nil),       // 9: hybridtheory.iabtcf.v1.AdditionalConsent
+	(*TCFCanadaConsent)(nil),        // 10: hybridtheory.iabtcf.v1.TCFCanadaConsent
+	(*TCFCanadaLegalBases)(nil),     // 11: hybridtheory.iabtcf.v1.TCFCanadaLegalBases
+	(*TCFCanadaVendorSections)(nil), // 12: hybridtheory.iabtcf.v1.TCFCanadaVendorSections
+	(*TCFCanadaPublisherTC)(nil),    // 13: hybridtheory.iabtcf.v1.TCFCanadaPublisherTC
+	(*USSection)(nil),               // 14: hybridtheory.iabtcf.v1.USSection
+}
+var file_consent_proto_depIdxs = []int32{
+	1,  // 0: hybridtheory.iabtcf.v1.PrivacySignals.tcf:type_name -> hybridtheory.iabtcf.v1.TCFConsent
+	8,  // 1: hybridtheory.iabtcf.v1.PrivacySignals.us_privacy:type_name -> hybridtheory.iabtcf.v1.USPrivacy
+	9,  // 2: hybridtheory.iabtcf.v1.PrivacySignals.additional_consent:type_name -> hybridtheory.iabtcf.v1.AdditionalConsent
+	10, // 3: hybridtheory.iabtcf.v1.PrivacySignals.tcf_canada:type_name -> hybridtheory.iabtcf.v1.TCFCanadaConsent
+	14, // 4: hybridtheory.iabtcf.v1.PrivacySignals.us_sections:type_name -> hybridtheory.iabtcf.v1.USSection
+	2,  // 5: hybridtheory.iabtcf.v1.TCFConsent.purposes:type_name -> hybridtheory.iabtcf.v1.LegalBases
+	4,  // 6: hybridtheory.iabtcf.v1.TCFConsent.vendors:type_name -> hybridtheory.iabtcf.v1.VendorSections
+	5,  // 7: hybridtheory.iabtcf.v1.TCFConsent.publisher_restrictions:type_name -> hybridtheory.iabtcf.v1.PublisherRestriction
+	6,  // 8: hybridtheory.iabtcf.v1.TCFConsent.segments:type_name -> hybridtheory.iabtcf.v1.Segments
+	3,  // 9: hybridtheory.iabtcf.v1.VendorSections.consents:type_name -> hybridtheory.iabtcf.v1.VendorList
+	3,  // 10: hybridtheory.iabtcf.v1.VendorSections.legitimate_interests:type_name -> hybridtheory.iabtcf.v1.VendorList
+	3,  // 11: hybridtheory.iabtcf.v1.Segments.disclosed_vendors:type_name -> hybridtheory.iabtcf.v1.VendorList
+	3,  // 12: hybridtheory.iabtcf.v1.Segments.allowed_vendors:type_name -> hybridtheory.iabtcf.v1.VendorList
+	7,  // 13: hybridtheory.iabtcf.v1.Segments.publisher_tc:type_name -> hybridtheory.iabtcf.v1.PublisherTC
+	2,  // 14: hybridtheory.iabtcf.v1.PublisherTC.purposes:type_name -> hybridtheory.iabtcf.v1.LegalBases
+	2,  // 15: hybridtheory.iabtcf.v1.PublisherTC.custom_purposes:type_name -> hybridtheory.iabtcf.v1.LegalBases
+	11, // 16: hybridtheory.iabtcf.v1.TCFCanadaConsent.purposes:type_name -> hybridtheory.iabtcf.v1.TCFCanadaLegalBases
+	12, // 17: hybridtheory.iabtcf.v1.TCFCanadaConsent.vendors:type_name -> hybridtheory.iabtcf.v1.TCFCanadaVendorSections
+	5,  // 18: hybridtheory.iabtcf.v1.TCFCanadaConsent.publisher_restrictions:type_name -> hybridtheory.iabtcf.v1.PublisherRestriction
+	13, // 19: hybridtheory.iabtcf.v1.TCFCanadaConsent.publisher_tc:type_name -> hybridtheory.iabtcf.v1.TCFCanadaPublisherTC
+	3,  // 20: hybridtheory.iabtcf.v1.TCFCanadaVendorSections.express_consents:type_name -> hybridtheory.iabtcf.v1.VendorList
+	3,  // 21: hybridtheory.iabtcf.v1.TCFCanadaVendorSections.implied_consents:type_name -> hybridtheory.iabtcf.v1.VendorList
+	11, // 22: hybridtheory.iabtcf.v1.TCFCanadaPublisherTC.purposes:type_name -> hybridtheory.iabtcf.v1.TCFCanadaLegalBases
+	11, // 23: hybridtheory.iabtcf.v1.TCFCanadaPublisherTC.custom_purposes:type_name -> hybridtheory.iabtcf.v1.TCFCanadaLegalBases
+	24, // [24:24] is the sub-list for method output_type
+	24, // [24:24] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_consent_proto_init() }
+func file_consent_proto_init() {
+	if File_consent_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_consent_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*PrivacySignals); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*TCFConsent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*LegalBases); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*VendorList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*VendorSections); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*PublisherRestriction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*Segments); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*PublisherTC); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*USPrivacy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*AdditionalConsent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*TCFCanadaConsent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*TCFCanadaLegalBases); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*TCFCanadaVendorSections); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*TCFCanadaPublisherTC); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consent_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*USSection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_consent_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_consent_proto_goTypes,
+		DependencyIndexes: file_consent_proto_depIdxs,
+		MessageInfos:      file_consent_proto_msgTypes,
+	}.Build()
+	File_consent_proto = out.File
+	file_consent_proto_rawDesc = nil
+	file_consent_proto_goTypes = nil
+	file_consent_proto_depIdxs = nil
+}
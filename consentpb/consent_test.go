@@ -0,0 +1,258 @@
+package consentpb_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/LiveRamp/iabconsent"
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/addtlconsent"
+	"github.com/hybridtheory/iab-tcf/consentpb"
+	"github.com/hybridtheory/iab-tcf/gpp"
+	"github.com/hybridtheory/iab-tcf/usprivacy"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var _ = Describe("Protobuf", func() {
+
+	// roundTrip returns the consent after converting it to a message, encoding and
+	// decoding it, and converting it back.
+	roundTrip := func(consent iab_tcf.Consent) iab_tcf.Consent {
+		m, err := consentpb.FromConsent(consent)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := proto.Marshal(m)
+		Expect(err).NotTo(HaveOccurred())
+		decoded := &consentpb.TCFConsent{}
+		Expect(proto.Unmarshal(data, decoded)).To(Succeed())
+		result, err := decoded.ToConsent()
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	DescribeTable("converts the consents to messages and back without losing information",
+		func(value string) {
+			consent, err := iab_tcf.NewConsent(value)
+			Expect(err).NotTo(HaveOccurred())
+			expected, err := json.Marshal(consent)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(json.Marshal(roundTrip(consent))).To(MatchJSON(expected))
+		},
+		Entry("with TCF 2.0 publisher restrictions", "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"),
+		Entry("with TCF 2.0 ranges and a disclosed vendors segment", "COytyllOytyllCrAAAENAiCMAFVAACqAAAAAF3QAgAFABkAAoioAAA.IF5EX2S5OI2tho2YdF7BEYYwfJxyigMgShgQIsS8NwIeFbBoGPmAAHBG4JAQAGBAkkACBAQIsHGBcCQABgIgRiRCMQEGMjzNKBJBAggkbI0FACCVmnkHS3ZCY70-6u__bA"),
+		Entry("with TCF 1.0", "BOlLbqtOlLbqtAVABADECg-AAAApp7v______9______9uz_Ov_v_f__33e8__9v_l_7_-___u_-3zd4u_1vf99yfm1-7etr3tp_87ues2_Xur__79__3z3_9phP78k89r7337Ew-v02"),
+	)
+
+	It("contains the decoded fields of the consent", func() {
+		consent, err := iab_tcf.NewConsent("CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA")
+		Expect(err).NotTo(HaveOccurred())
+		m, err := consentpb.FromConsent(consent)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.TcfVersion).To(BeEquivalentTo(2))
+		Expect(m.CmpId).To(BeEquivalentTo(10))
+		Expect(m.CreatedUnixMillis).To(BeEquivalentTo(1500000000000))
+		Expect(m.ConsentLanguage).To(Equal("EN"))
+		Expect(m.Purposes.Consents).To(Equal([]uint32{1, 2, 3, 4}))
+		Expect(m.SpecialFeatureOptIns).To(Equal([]uint32{1}))
+		Expect(proto.Equal(m.Vendors.Consents, &consentpb.VendorList{MaxVendorId: 10, Ids: []uint32{5}})).To(BeTrue())
+		Expect(m.PublisherRestrictions).To(HaveLen(1))
+		Expect(proto.Equal(m.PublisherRestrictions[0], &consentpb.PublisherRestriction{PurposeId: 1, VendorIds: []uint32{5}})).To(BeTrue())
+	})
+
+	It("returns the error of the vendor sections of lazily decoded consents", func() {
+		consent, err := iab_tcf.NewConsent("CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAho", iab_tcf.WithLazyDecoding())
+		Expect(err).NotTo(HaveOccurred())
+		_, err = consentpb.FromConsent(consent)
+		Expect(err).To(MatchError(iab_tcf.ErrUnexpectedEnd))
+	})
+
+	It("encodes the messages following consent.proto", func() {
+		data, err := proto.Marshal(&consentpb.TCFConsent{
+			TcfVersion: 2,
+			Vendors:    &consentpb.VendorSections{Consents: &consentpb.VendorList{MaxVendorId: 10, Ids: []uint32{5, 7}}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal([]byte{0x08, 0x02, 0x82, 0x01, 0x08, 0x0a, 0x06, 0x08, 0x0a, 0x1a, 0x02, 0x05, 0x07}))
+	})
+
+	It("decodes unpacked repeated fields and skips unknown fields", func() {
+		var data []byte
+		data = protowire.AppendTag(data, 3, protowire.VarintType)
+		data = protowire.AppendVarint(data, 5)
+		data = protowire.AppendTag(data, 9, protowire.Fixed32Type)
+		data = protowire.AppendFixed32(data, 1)
+		data = protowire.AppendTag(data, 3, protowire.VarintType)
+		data = protowire.AppendVarint(data, 7)
+		m := &consentpb.VendorList{}
+		Expect(proto.Unmarshal(data, m)).To(Succeed())
+		Expect(m.Ids).To(Equal([]uint32{5, 7}))
+	})
+
+	It("fails with truncated messages", func() {
+		data, err := proto.Marshal(&consentpb.TCFConsent{TcfVersion: 2, ConsentLanguage: "EN"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Unmarshal(data[:len(data)-1], &consentpb.TCFConsent{})).NotTo(Succeed())
+	})
+
+	It("fails to convert messages with values out of range", func() {
+		m := &consentpb.TCFConsent{TcfVersion: 2, Purposes: &consentpb.LegalBases{Consents: []uint32{25}}}
+		_, err := m.ToConsent()
+		Expect(errors.Is(err, iab_tcf.ErrValueOutOfRange)).To(BeTrue())
+		_, err = (&consentpb.TCFConsent{TcfVersion: 3}).ToConsent()
+		Expect(err).To(Equal(iab_tcf.ErrInvalidVersion))
+	})
+
+	It("fails to convert consents other than the TCF 1.0 and 2.0 ones", func() {
+		_, err := consentpb.FromConsent(&gpp.TCFCanada{})
+		Expect(err).To(MatchError(consentpb.ErrUnsupportedConsent))
+	})
+
+	It("converts the TCF Canada sections", func() {
+		consent, err := gpp.NewTCFCanada("BO5rKAAO5rKAAAyACDENAwCQAYAAAGAAAAAVACgAEAAgACgAGBAAgoAMAAwAEA.cAAACAAAAUg")
+		Expect(err).NotTo(HaveOccurred())
+		data, err := proto.Marshal(consentpb.FromTCFCanada(consent))
+		Expect(err).NotTo(HaveOccurred())
+		m := &consentpb.TCFCanadaConsent{}
+		Expect(proto.Unmarshal(data, m)).To(Succeed())
+		Expect(proto.Equal(m, &consentpb.TCFCanadaConsent{
+			SectionVersion:                1,
+			CreatedUnixMillis:             1600000000000,
+			LastUpdatedUnixMillis:         1600000000000,
+			CmpId:                         50,
+			CmpVersion:                    2,
+			ConsentScreen:                 3,
+			ConsentLanguage:               "EN",
+			VendorListVersion:             48,
+			TcfPolicyVersion:              2,
+			Purposes:                      &consentpb.TCFCanadaLegalBases{ExpressConsents: []uint32{1, 2}, ImpliedConsents: []uint32{3, 4}},
+			SpecialFeatureExpressConsents: []uint32{1},
+			Vendors: &consentpb.TCFCanadaVendorSections{
+				ExpressConsents: &consentpb.VendorList{MaxVendorId: 10, IsRangeEncoding: true, Ids: []uint32{2, 3, 4, 10}},
+				ImpliedConsents: &consentpb.VendorList{MaxVendorId: 6, Ids: []uint32{5}},
+			},
+			PublisherRestrictions: []*consentpb.PublisherRestriction{{PurposeId: 1, RestrictionType: 1, VendorIds: []uint32{3, 4}}},
+			PublisherTc: &consentpb.TCFCanadaPublisherTC{
+				Purposes:          &consentpb.TCFCanadaLegalBases{ExpressConsents: []uint32{1}, ImpliedConsents: []uint32{2}},
+				NumCustomPurposes: 2,
+				CustomPurposes:    &consentpb.TCFCanadaLegalBases{ExpressConsents: []uint32{1}, ImpliedConsents: []uint32{2}},
+			},
+		})).To(BeTrue())
+	})
+
+	It("converts the US sections", func() {
+		section, err := gpp.NewUSNational("BVVqAAEABCA.YA")
+		Expect(err).NotTo(HaveOccurred())
+		m, err := consentpb.FromUSSection(section)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.SectionId).To(BeEquivalentTo(gpp.USNationalSID))
+		Expect(m.Version).To(BeEquivalentTo(1))
+		Expect(m.SaleOptOut).To(BeEquivalentTo(iabconsent.NotOptedOut))
+		Expect(m.SensitiveDataProcessingConsents).To(HaveLen(12))
+		Expect(m.SensitiveDataProcessingConsents[7]).To(BeEquivalentTo(iabconsent.NoConsent))
+		Expect(m.SensitiveDataProcessingOptOuts).To(BeEmpty())
+		Expect(m.Gpc).To(BeTrue())
+		_, err = consentpb.FromUSSection(usSection{section})
+		Expect(err).To(Equal(consentpb.ErrUnsupportedConsent))
+	})
+
+	It("converts the US Privacy consents", func() {
+		consent, err := usprivacy.NewConsent("1YN-")
+		Expect(err).NotTo(HaveOccurred())
+		m := &consentpb.USPrivacy{}
+		data, err := proto.Marshal(consentpb.FromUSPrivacy(consent))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Unmarshal(data, m)).To(Succeed())
+		Expect(m.OptOutSale).To(Equal("N"))
+		Expect(m.ToConsent()).To(Equal(consent))
+		_, err = (&consentpb.USPrivacy{Version: 1, Notice: "Y", OptOutSale: "maybe", LspaCovered: "-"}).ToConsent()
+		Expect(err).To(Equal(usprivacy.ErrInvalidFlag))
+	})
+
+	It("converts the Additional Consent strings", func() {
+		consent, err := addtlconsent.NewConsent("2~1.35.41~dv.9.21")
+		Expect(err).NotTo(HaveOccurred())
+		m := &consentpb.AdditionalConsent{}
+		data, err := proto.Marshal(consentpb.FromAdditionalConsent(consent))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Unmarshal(data, m)).To(Succeed())
+		Expect(m.ConsentedProviderIds).To(Equal([]uint32{1, 35, 41}))
+		Expect(m.ToConsent()).To(Equal(consent))
+		_, err = (&consentpb.AdditionalConsent{Version: 1, DisclosedProviderIds: []uint32{9}}).ToConsent()
+		Expect(err).To(Equal(addtlconsent.ErrInvalidFormat))
+	})
+
+	It("groups all the privacy signals", func() {
+		signals := &consentpb.PrivacySignals{
+			UsPrivacy:         &consentpb.USPrivacy{Version: 1, Notice: "Y", OptOutSale: "Y", LspaCovered: "N"},
+			AdditionalConsent: &consentpb.AdditionalConsent{Version: 1, ConsentedProviderIds: []uint32{89}},
+			TcfCanada:         &consentpb.TCFCanadaConsent{SectionVersion: 1, CmpId: 50},
+			UsSections:        []*consentpb.USSection{{SectionId: 7, Version: 1, Gpc: true}, {SectionId: 8, Version: 1}},
+		}
+		data, err := proto.Marshal(signals)
+		Expect(err).NotTo(HaveOccurred())
+		decoded := &consentpb.PrivacySignals{}
+		Expect(proto.Unmarshal(data, decoded)).To(Succeed())
+		Expect(proto.Equal(decoded, signals)).To(BeTrue())
+	})
+})
+
+var _ = Describe("consent.proto", func() {
+
+	var (
+		messageRegexp = regexp.MustCompile(`^message (\w+) \{`)
+		fieldRegexp   = regexp.MustCompile(`^\s+(repeated )?(\w+) (\w+) = (\d+);`)
+	)
+
+	// fields returns the fields of every message of consent.proto as `type name = number`,
+	// with the message types by their name.
+	fields := func(messages protoreflect.MessageDescriptors) map[string][]string {
+		result := map[string][]string{}
+		for i := 0; i < messages.Len(); i++ {
+			message := messages.Get(i)
+			result[string(message.Name())] = []string{}
+			for j := 0; j < message.Fields().Len(); j++ {
+				field := message.Fields().Get(j)
+				typ := field.Kind().String()
+				if field.Message() != nil {
+					typ = string(field.Message().Name())
+				}
+				if field.Cardinality() == protoreflect.Repeated {
+					typ = "repeated " + typ
+				}
+				result[string(message.Name())] = append(result[string(message.Name())],
+					fmt.Sprintf("%s %s = %d", typ, field.Name(), field.Number()))
+			}
+		}
+		return result
+	}
+
+	It("is in sync with the generated code", func() {
+		data, err := os.ReadFile("consent.proto")
+		Expect(err).NotTo(HaveOccurred())
+		expected := map[string][]string{}
+		message := ""
+		for _, line := range strings.Split(string(data), "\n") {
+			if match := messageRegexp.FindStringSubmatch(line); match != nil {
+				message = match[1]
+				expected[message] = []string{}
+			} else if match := fieldRegexp.FindStringSubmatch(line); match != nil {
+				expected[message] = append(expected[message], fmt.Sprintf("%s%s %s = %s", match[1], match[2], match[3], match[4]))
+			}
+		}
+		Expect(expected).NotTo(BeEmpty())
+		Expect(fields(consentpb.File_consent_proto.Messages())).To(Equal(expected),
+			"consent.proto changed, run go generate ./consentpb")
+	})
+})
+
+// usSection is a US section implemented outside the library.
+type usSection struct {
+	gpp.USSection
+}
@@ -0,0 +1,567 @@
+// Package consentpb contains the messages of consent.proto, to send the decoded privacy
+// signals through protobuf-based pipelines, with the conversions to and from the
+// consents of the library.
+//
+// The messages are generated with protoc-gen-go, so they are regular proto.Message
+// values to encode with proto.Marshal, and compatible with the code generated from
+// consent.proto in any other language.
+package consentpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative consent.proto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LiveRamp/iabconsent"
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/addtlconsent"
+	"github.com/hybridtheory/iab-tcf/gpp"
+	"github.com/hybridtheory/iab-tcf/usprivacy"
+)
+
+const (
+	// numPurposes is the number of purposes encoded in the TCF consent strings.
+	numPurposes = 24
+	// numSpecialFeatures is the number of special features encoded in TCF 2.0 consent strings.
+	numSpecialFeatures = 12
+	// maxVendorID is the greatest vendor ID that can be encoded in TCF consent strings.
+	maxVendorID = 1<<16 - 1
+)
+
+// ErrUnsupportedConsent is returned when converting a consent other than the TCF 1.0
+// and 2.0 ones of the library to a TCFConsent, or a US section other than the ones of
+// the gpp package to a USSection.
+var ErrUnsupportedConsent = errors.New("Unsupported consent type")
+
+// header is implemented by the consents with the metadata of TCF 1.0 and 2.0 strings.
+type header interface {
+	iab_tcf.Consent
+	Created() time.Time
+	LastUpdated() time.Time
+	CMPVersion() int
+	ConsentScreen() int
+	ConsentLanguage() string
+	VendorListVersion() int
+}
+
+// FromConsent returns the message of a TCF 1.0 or 2.0 consent, with the same information
+// as its JSON representation. It returns the error of the vendor sections of lazily
+// decoded consents, and ErrUnsupportedConsent for the rest of the consents, e.g. the
+// TCF Canada sections, converted with FromTCFCanada instead.
+func FromConsent(consent iab_tcf.Consent) (*TCFConsent, error) {
+	switch c := consent.(type) {
+	case *iab_tcf.ConsentV1:
+		p := c.ToParsedConsent()
+		m := newTCFConsent(c)
+		m.Purposes = &LegalBases{Consents: ids(numPurposes, c.HasConsentedPurpose)}
+		m.Vendors = &VendorSections{
+			Consents: &VendorList{
+				MaxVendorId:     uint32(p.MaxVendorID),
+				IsRangeEncoding: p.IsRangeEncoding,
+				Ids:             ids(p.MaxVendorID, c.HasUserConsented),
+			},
+		}
+		return m, nil
+	case *iab_tcf.ConsentV2:
+		if err := c.Err(); err != nil {
+			return nil, err
+		}
+		m := newTCFConsent(c)
+		m.TcfPolicyVersion = uint32(c.TCFPolicyVersion())
+		m.IsServiceSpecific = c.IsServiceSpecific()
+		m.UseNonStandardStacks = c.UseNonStandardStacks()
+		m.PurposeOneTreatment = c.PurposeOneTreatment()
+		m.PublisherCc = c.PublisherCC()
+		m.Purposes = &LegalBases{
+			Consents:            ids(numPurposes, c.HasConsentedPurpose),
+			LegitimateInterests: ids(numPurposes, c.HasConsentedLegitimateInterestForPurpose),
+		}
+		m.SpecialFeatureOptIns = ids(numSpecialFeatures, c.HasSpecialFeatureOptIn)
+		m.Vendors = &VendorSections{
+			Consents: &VendorList{
+				MaxVendorId:     uint32(c.MaxConsentVendorID()),
+				IsRangeEncoding: c.IsConsentRangeEncoding(),
+				Ids:             toUint32s(c.ConsentedVendorSet().IDs()),
+			},
+			LegitimateInterests: &VendorList{
+				MaxVendorId:     uint32(c.MaxInterestsVendorID()),
+				IsRangeEncoding: c.IsInterestsRangeEncoding(),
+				Ids:             toUint32s(c.LegitimateInterestVendorSet().IDs()),
+			},
+		}
+		for _, restriction := range c.GetPublisherRestrictions() {
+			m.PublisherRestrictions = append(m.PublisherRestrictions, &PublisherRestriction{
+				PurposeId:       uint32(restriction.PurposeID),
+				RestrictionType: uint32(restriction.RestrictionType),
+				VendorIds:       toUint32s(iab_tcf.NewVendorSetFromRanges(restriction.RestrictionsRange).IDs()),
+			})
+		}
+		m.Segments = newSegments(c)
+		return m, nil
+	case *gpp.TCFCanada:
+		return nil, fmt.Errorf("%w: convert TCF Canada sections with FromTCFCanada", ErrUnsupportedConsent)
+	}
+	return nil, ErrUnsupportedConsent
+}
+
+// FromTCFCanada returns the message of a TCF Canada section, with the same information as
+// its JSON representation. There's no conversion back, as the sections can't be encoded.
+func FromTCFCanada(consent *gpp.TCFCanada) *TCFCanadaConsent {
+	p := consent.ParsedConsent
+	m := &TCFCanadaConsent{
+		SectionVersion:        uint32(consent.SectionVersion()),
+		CreatedUnixMillis:     unixMillis(p.Created),
+		LastUpdatedUnixMillis: unixMillis(p.LastUpdated),
+		CmpId:                 uint32(p.CMPID),
+		CmpVersion:            uint32(p.CMPVersion),
+		ConsentScreen:         uint32(p.ConsentScreen),
+		ConsentLanguage:       p.ConsentLanguage,
+		VendorListVersion:     uint32(p.VendorListVersion),
+		TcfPolicyVersion:      uint32(p.TCFPolicyVersion),
+		UseNonStandardStacks:  p.UseNonStandardStacks,
+		Purposes: &TCFCanadaLegalBases{
+			ExpressConsents: idsFromMap(numPurposes, p.PurposesExpressConsent),
+			ImpliedConsents: idsFromMap(numPurposes, p.PurposesImpliedConsent),
+		},
+		SpecialFeatureExpressConsents: idsFromMap(numSpecialFeatures, p.SpecialFeatureExpressConsent),
+		Vendors: &TCFCanadaVendorSections{
+			ExpressConsents: &VendorList{
+				MaxVendorId:     uint32(p.MaxExpressVendorID),
+				IsRangeEncoding: p.IsExpressRangeEncoding,
+				Ids:             toUint32s(consent.ExpressConsentVendorSet().IDs()),
+			},
+			ImpliedConsents: &VendorList{
+				MaxVendorId:     uint32(p.MaxImpliedVendorID),
+				IsRangeEncoding: p.IsImpliedRangeEncoding,
+				Ids:             toUint32s(consent.ImpliedConsentVendorSet().IDs()),
+			},
+		},
+	}
+	for _, restriction := range consent.GetPublisherRestrictions() {
+		m.PublisherRestrictions = append(m.PublisherRestrictions, &PublisherRestriction{
+			PurposeId:       uint32(restriction.PurposeID),
+			RestrictionType: uint32(restriction.RestrictionType),
+			VendorIds:       toUint32s(iab_tcf.NewVendorSetFromRanges(restriction.RestrictionsRange).IDs()),
+		})
+	}
+	if p.PubPurposesExpressConsent != nil || p.PubPurposesImpliedConsent != nil {
+		m.PublisherTc = &TCFCanadaPublisherTC{
+			Purposes: &TCFCanadaLegalBases{
+				ExpressConsents: idsFromMap(numPurposes, p.PubPurposesExpressConsent),
+				ImpliedConsents: idsFromMap(numPurposes, p.PubPurposesImpliedConsent),
+			},
+			NumCustomPurposes: uint32(p.NumCustomPurposes),
+			CustomPurposes: &TCFCanadaLegalBases{
+				ExpressConsents: idsFromMap(p.NumCustomPurposes, p.CustomPurposesExpressConsent),
+				ImpliedConsents: idsFromMap(p.NumCustomPurposes, p.CustomPurposesImpliedConsent),
+			},
+		}
+	}
+	return m
+}
+
+// FromUSSection returns the message of a US National or US state section of the gpp
+// package, or ErrUnsupportedConsent for other implementations of gpp.USSection. There's
+// no conversion back, as the sections can't be encoded.
+func FromUSSection(section gpp.USSection) (*USSection, error) {
+	var p *iabconsent.MspaParsedConsent
+	switch s := section.(type) {
+	case *gpp.USNational:
+		p = s.ParsedConsent
+	case *gpp.USCalifornia:
+		p = s.ParsedConsent
+	case *gpp.USVirginia:
+		p = s.ParsedConsent
+	case *gpp.USColorado:
+		p = s.ParsedConsent
+	case *gpp.USUtah:
+		p = s.ParsedConsent
+	case *gpp.USConnecticut:
+		p = s.ParsedConsent
+	default:
+		return nil, ErrUnsupportedConsent
+	}
+	return &USSection{
+		SectionId:                           uint32(section.SectionID()),
+		Version:                             uint32(p.Version),
+		SharingNotice:                       uint32(p.SharingNotice),
+		SaleOptOutNotice:                    uint32(p.SaleOptOutNotice),
+		SharingOptOutNotice:                 uint32(p.SharingOptOutNotice),
+		TargetedAdvertisingOptOutNotice:     uint32(p.TargetedAdvertisingOptOutNotice),
+		SensitiveDataProcessingOptOutNotice: uint32(p.SensitiveDataProcessingOptOutNotice),
+		SensitiveDataLimitUseNotice:         uint32(p.SensitiveDataLimitUseNotice),
+		SaleOptOut:                          uint32(p.SaleOptOut),
+		SharingOptOut:                       uint32(p.SharingOptOut),
+		TargetedAdvertisingOptOut:           uint32(p.TargetedAdvertisingOptOut),
+		SensitiveDataProcessingConsents:     byCategory(p.SensitiveDataProcessingConsents),
+		SensitiveDataProcessingOptOuts:      byCategory(p.SensitiveDataProcessingOptOuts),
+		KnownChildSensitiveDataConsents:     byCategory(p.KnownChildSensitiveDataConsents),
+		PersonalDataConsents:                uint32(p.PersonalDataConsents),
+		MspaCoveredTransaction:              uint32(p.MspaCoveredTransaction),
+		MspaOptOutOptionMode:                uint32(p.MspaOptOutOptionMode),
+		MspaServiceProviderMode:             uint32(p.MspaServiceProviderMode),
+		Gpc:                                 p.Gpc,
+	}, nil
+}
+
+// newTCFConsent returns the message with the metadata shared by every TCF version.
+func newTCFConsent(consent header) *TCFConsent {
+	return &TCFConsent{
+		TcfVersion:            uint32(consent.Version()),
+		CreatedUnixMillis:     unixMillis(consent.Created()),
+		LastUpdatedUnixMillis: unixMillis(consent.LastUpdated()),
+		CmpId:                 uint32(consent.CMPID()),
+		CmpVersion:            uint32(consent.CMPVersion()),
+		ConsentScreen:         uint32(consent.ConsentScreen()),
+		ConsentLanguage:       consent.ConsentLanguage(),
+		VendorListVersion:     uint32(consent.VendorListVersion()),
+	}
+}
+
+// newSegments returns the segments following the core one of the consent, or nil if
+// there are none.
+func newSegments(consent *iab_tcf.ConsentV2) *Segments {
+	segments := &Segments{
+		DisclosedVendors: newVendorList(consent.DisclosedVendors()),
+		AllowedVendors:   newVendorList(consent.AllowedVendors()),
+	}
+	if entry := consent.PublisherTC(); entry != nil {
+		segments.PublisherTc = &PublisherTC{
+			Purposes: &LegalBases{
+				Consents:            idsFromMap(numPurposes, entry.PubPurposesConsent),
+				LegitimateInterests: idsFromMap(numPurposes, entry.PubPurposesLITransparency),
+			},
+			NumCustomPurposes: uint32(entry.NumCustomPurposes),
+			CustomPurposes: &LegalBases{
+				Consents:            idsFromMap(entry.NumCustomPurposes, entry.CustomPurposesConsent),
+				LegitimateInterests: idsFromMap(entry.NumCustomPurposes, entry.CustomPurposesLITransparency),
+			},
+		}
+	}
+	if segments.DisclosedVendors == nil && segments.AllowedVendors == nil && segments.PublisherTc == nil {
+		return nil
+	}
+	return segments
+}
+
+// newVendorList returns the message of the vendor list of a segment, if any.
+func newVendorList(vendors *iabconsent.OOBVendorList) *VendorList {
+	if vendors == nil {
+		return nil
+	}
+	m := &VendorList{
+		MaxVendorId:     uint32(vendors.MaxVendorID),
+		IsRangeEncoding: vendors.IsRangeEncoding,
+		Ids:             idsFromMap(vendors.MaxVendorID, vendors.Vendors),
+	}
+	if vendors.IsRangeEncoding {
+		m.Ids = toUint32s(iab_tcf.NewVendorSetFromRanges(vendors.VendorEntries).IDs())
+	}
+	return m
+}
+
+// ToConsent returns the consent of the message, encoded and decoded again so it's
+// validated as the consent strings. It returns ErrValueOutOfRange with IDs out of their
+// range and ErrInvalidVersion with unknown TCF versions.
+func (m *TCFConsent) ToConsent() (iab_tcf.Consent, error) {
+	var value string
+	var err error
+	switch iabconsent.TCFVersion(m.GetTcfVersion()) {
+	case iabconsent.V1:
+		value, err = m.encodeV1()
+	case iabconsent.V2:
+		value, err = m.encodeV2()
+	default:
+		return nil, iab_tcf.ErrInvalidVersion
+	}
+	if err != nil {
+		return nil, err
+	}
+	return iab_tcf.NewConsent(value)
+}
+
+// encodeV1 returns the TCF 1.0 consent string of the message.
+func (m *TCFConsent) encodeV1() (string, error) {
+	purposes, err := mapFromIDs(m.GetPurposes().GetConsents(), numPurposes, "purpose")
+	if err != nil {
+		return "", err
+	}
+	vendors, err := m.GetVendors().GetConsents().toVendorList()
+	if err != nil {
+		return "", err
+	}
+	return iab_tcf.EncodeV1(&iabconsent.ParsedConsent{
+		Version:           int(iabconsent.V1),
+		Created:           fromUnixMillis(m.GetCreatedUnixMillis()),
+		LastUpdated:       fromUnixMillis(m.GetLastUpdatedUnixMillis()),
+		CMPID:             int(m.GetCmpId()),
+		CMPVersion:        int(m.GetCmpVersion()),
+		ConsentScreen:     int(m.GetConsentScreen()),
+		ConsentLanguage:   m.GetConsentLanguage(),
+		VendorListVersion: int(m.GetVendorListVersion()),
+		PurposesAllowed:   purposes,
+		MaxVendorID:       vendors.MaxVendorID,
+		IsRangeEncoding:   vendors.IsRangeEncoding,
+		ConsentedVendors:  vendors.Vendors,
+		NumEntries:        vendors.NumEntries,
+		RangeEntries:      vendors.VendorEntries,
+	})
+}
+
+// encodeV2 returns the TCF 2.0 consent string of the message, with its segments.
+func (m *TCFConsent) encodeV2() (string, error) {
+	p := &iabconsent.V2ParsedConsent{
+		Version:              int(iabconsent.V2),
+		Created:              fromUnixMillis(m.GetCreatedUnixMillis()),
+		LastUpdated:          fromUnixMillis(m.GetLastUpdatedUnixMillis()),
+		CMPID:                int(m.GetCmpId()),
+		CMPVersion:           int(m.GetCmpVersion()),
+		ConsentScreen:        int(m.GetConsentScreen()),
+		ConsentLanguage:      m.GetConsentLanguage(),
+		VendorListVersion:    int(m.GetVendorListVersion()),
+		TCFPolicyVersion:     int(m.GetTcfPolicyVersion()),
+		IsServiceSpecific:    m.GetIsServiceSpecific(),
+		UseNonStandardStacks: m.GetUseNonStandardStacks(),
+		PurposeOneTreatment:  m.GetPurposeOneTreatment(),
+		PublisherCC:          m.GetPublisherCc(),
+	}
+	var err error
+	if p.PurposesConsent, err = mapFromIDs(m.GetPurposes().GetConsents(), numPurposes, "purpose"); err != nil {
+		return "", err
+	}
+	if p.PurposesLITransparency, err = mapFromIDs(m.GetPurposes().GetLegitimateInterests(), numPurposes, "purpose"); err != nil {
+		return "", err
+	}
+	if p.SpecialFeaturesOptIn, err = mapFromIDs(m.GetSpecialFeatureOptIns(), numSpecialFeatures, "special feature"); err != nil {
+		return "", err
+	}
+	consents, err := m.GetVendors().GetConsents().toVendorList()
+	if err != nil {
+		return "", err
+	}
+	p.MaxConsentVendorID, p.IsConsentRangeEncoding = consents.MaxVendorID, consents.IsRangeEncoding
+	p.ConsentedVendors, p.ConsentedVendorsRange = consents.Vendors, consents.VendorEntries
+	interests, err := m.GetVendors().GetLegitimateInterests().toVendorList()
+	if err != nil {
+		return "", err
+	}
+	p.MaxInterestsVendorID, p.IsInterestsRangeEncoding = interests.MaxVendorID, interests.IsRangeEncoding
+	p.InterestsVendors, p.InterestsVendorsRange = interests.Vendors, interests.VendorEntries
+	for _, restriction := range m.GetPublisherRestrictions() {
+		if _, err := mapFromIDs(restriction.GetVendorIds(), maxVendorID, "restriction vendor"); err != nil {
+			return "", err
+		}
+		entries := vendorSet(restriction.GetVendorIds()).Ranges()
+		p.PubRestrictionEntries = append(p.PubRestrictionEntries, &iabconsent.PubRestrictionEntry{
+			PurposeID:         int(restriction.GetPurposeId()),
+			RestrictionType:   iabconsent.RestrictionType(restriction.GetRestrictionType()),
+			NumEntries:        len(entries),
+			RestrictionsRange: entries,
+		})
+	}
+	p.NumPubRestrictions = len(p.PubRestrictionEntries)
+	if segment := m.GetSegments().GetDisclosedVendors(); segment != nil {
+		if p.OOBDisclosedVendors, err = segment.toVendorList(); err != nil {
+			return "", err
+		}
+		p.OOBDisclosedVendors.SegmentType = iabconsent.DisclosedVendors
+	}
+	if segment := m.GetSegments().GetAllowedVendors(); segment != nil {
+		if p.OOBAllowedVendors, err = segment.toVendorList(); err != nil {
+			return "", err
+		}
+		p.OOBAllowedVendors.SegmentType = iabconsent.AllowedVendors
+	}
+	if segment := m.GetSegments().GetPublisherTc(); segment != nil {
+		if p.PublisherTCEntry, err = segment.toPublisherTCEntry(); err != nil {
+			return "", err
+		}
+	}
+	return iab_tcf.EncodeV2(p)
+}
+
+// toVendorList returns the vendor list of the message, either as a bit field or as
+// range entries.
+func (m *VendorList) toVendorList() (*iabconsent.OOBVendorList, error) {
+	vendors := &iabconsent.OOBVendorList{
+		MaxVendorID:     int(m.GetMaxVendorId()),
+		IsRangeEncoding: m.GetIsRangeEncoding(),
+	}
+	var err error
+	if vendors.Vendors, err = mapFromIDs(m.GetIds(), vendors.MaxVendorID, "vendor"); err != nil {
+		return nil, err
+	}
+	if vendors.IsRangeEncoding {
+		vendors.VendorEntries, vendors.Vendors = vendorSet(m.GetIds()).Ranges(), nil
+		vendors.NumEntries = len(vendors.VendorEntries)
+	}
+	return vendors, nil
+}
+
+// toPublisherTCEntry returns the publisher purposes segment of the message.
+func (m *PublisherTC) toPublisherTCEntry() (*iabconsent.PublisherTCEntry, error) {
+	entry := &iabconsent.PublisherTCEntry{
+		SegmentType:       iabconsent.PublisherTC,
+		NumCustomPurposes: int(m.GetNumCustomPurposes()),
+	}
+	fields := []struct {
+		target *map[int]bool
+		ids    []uint32
+		max    int
+		field  string
+	}{
+		{&entry.PubPurposesConsent, m.GetPurposes().GetConsents(), numPurposes, "purpose"},
+		{&entry.PubPurposesLITransparency, m.GetPurposes().GetLegitimateInterests(), numPurposes, "purpose"},
+		{&entry.CustomPurposesConsent, m.GetCustomPurposes().GetConsents(), entry.NumCustomPurposes, "custom purpose"},
+		{&entry.CustomPurposesLITransparency, m.GetCustomPurposes().GetLegitimateInterests(), entry.NumCustomPurposes, "custom purpose"},
+	}
+	for _, f := range fields {
+		values, err := mapFromIDs(f.ids, f.max, f.field)
+		if err != nil {
+			return nil, err
+		}
+		*f.target = values
+	}
+	return entry, nil
+}
+
+// FromUSPrivacy returns the message of a US Privacy consent.
+func FromUSPrivacy(consent *usprivacy.Consent) *USPrivacy {
+	return &USPrivacy{
+		Version:     uint32(consent.Version),
+		Notice:      string(consent.Notice),
+		OptOutSale:  string(consent.OptOutSale),
+		LspaCovered: string(consent.LSPACovered),
+	}
+}
+
+// ToConsent returns the US Privacy consent of the message. It returns the errors of
+// usprivacy.Consent.Validate if any of its fields is not valid.
+func (m *USPrivacy) ToConsent() (*usprivacy.Consent, error) {
+	consent := &usprivacy.Consent{
+		Version:     int(m.GetVersion()),
+		Notice:      toFlag(m.GetNotice()),
+		OptOutSale:  toFlag(m.GetOptOutSale()),
+		LSPACovered: toFlag(m.GetLspaCovered()),
+	}
+	if err := consent.Validate(); err != nil {
+		return nil, err
+	}
+	return consent, nil
+}
+
+// FromAdditionalConsent returns the message of an Additional Consent string.
+func FromAdditionalConsent(consent *addtlconsent.Consent) *AdditionalConsent {
+	return &AdditionalConsent{
+		Version:              uint32(consent.Version),
+		ConsentedProviderIds: toUint32s(consent.ConsentedProviders()),
+		DisclosedProviderIds: toUint32s(consent.DisclosedProviders()),
+	}
+}
+
+// ToConsent returns the Additional Consent of the message. It returns the errors of
+// addtlconsent.NewConsent if the message doesn't represent a valid string.
+func (m *AdditionalConsent) ToConsent() (*addtlconsent.Consent, error) {
+	consent := &addtlconsent.Consent{
+		Version:   int(m.GetVersion()),
+		Consented: map[int]bool{},
+		Disclosed: map[int]bool{},
+	}
+	for _, providerID := range m.GetConsentedProviderIds() {
+		consent.Consented[int(providerID)] = true
+	}
+	for _, providerID := range m.GetDisclosedProviderIds() {
+		consent.Disclosed[int(providerID)] = true
+	}
+	if consent.Version == addtlconsent.V1 && len(consent.Disclosed) > 0 {
+		return nil, addtlconsent.ErrInvalidFormat
+	}
+	return addtlconsent.NewConsent(consent.String())
+}
+
+// unixMillis returns the milliseconds since the Unix epoch of t, or 0 for the zero time.
+func unixMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// fromUnixMillis returns the UTC time of the milliseconds since the Unix epoch, or the
+// zero time for 0.
+func fromUnixMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
+
+// toFlag returns the US Privacy flag of its string, or an invalid one if it isn't a
+// single character.
+func toFlag(value string) usprivacy.Flag {
+	if len(value) != 1 {
+		return 0
+	}
+	return usprivacy.Flag(value[0])
+}
+
+// ids returns the sorted IDs from 1 to n for which has returns true.
+func ids(n int, has func(id int) bool) []uint32 {
+	values := []uint32{}
+	for id := 1; id <= n; id++ {
+		if has(id) {
+			values = append(values, uint32(id))
+		}
+	}
+	return values
+}
+
+// idsFromMap returns the sorted IDs from 1 to n set to true in the map.
+func idsFromMap(n int, values map[int]bool) []uint32 {
+	return ids(n, func(id int) bool { return values[id] })
+}
+
+// mapFromIDs returns the IDs as a map, as the parsed consents contain them. It returns
+// ErrValueOutOfRange if any of the IDs is not between 1 and max.
+func mapFromIDs(ids []uint32, max int, field string) (map[int]bool, error) {
+	values := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if id < 1 || int(id) > max {
+			return nil, fmt.Errorf("%w: %s ID %d must be between 1 and %d", iab_tcf.ErrValueOutOfRange, field, id, max)
+		}
+		values[int(id)] = true
+	}
+	return values, nil
+}
+
+// vendorSet returns the set of the vendor IDs of a message.
+func vendorSet(ids []uint32) iab_tcf.VendorSet {
+	values := make([]int, len(ids))
+	for i, id := range ids {
+		values[i] = int(id)
+	}
+	return iab_tcf.NewVendorSet(values...)
+}
+
+// byCategory returns the values of the categories of a US section, indexed from 0, in the
+// order of the categories.
+func byCategory[T ~int](values map[int]T) []uint32 {
+	result := make([]uint32, len(values))
+	for category, value := range values {
+		if category >= 0 && category < len(values) {
+			result[category] = uint32(value)
+		}
+	}
+	return result
+}
+
+// toUint32s returns the IDs as uint32, as encoded in the messages.
+func toUint32s(ids []int) []uint32 {
+	values := make([]uint32, len(ids))
+	for i, id := range ids {
+		values[i] = uint32(id)
+	}
+	return values
+}
@@ -0,0 +1,50 @@
+package iab_tcf
+
+import (
+	"context"
+)
+
+type contextKey int
+
+const (
+	consentKey contextKey = iota
+	consentStringKey
+	errorKey
+)
+
+// NewContext returns a copy of the context carrying the consent received.
+func NewContext(ctx context.Context, consent Consent) context.Context {
+	return context.WithValue(ctx, consentKey, consent)
+}
+
+// FromContext returns the consent stored in the context, if any.
+func FromContext(ctx context.Context) (Consent, bool) {
+	consent, ok := ctx.Value(consentKey).(Consent)
+	return consent, ok
+}
+
+// NewConsentStringContext returns a copy of the context carrying the raw consent string
+// received, e.g. to forward it to other services or to expand it in URLs.
+func NewConsentStringContext(ctx context.Context, value string) context.Context {
+	return context.WithValue(ctx, consentStringKey, value)
+}
+
+// ConsentStringFromContext returns the raw consent string stored in the context, no
+// matter if it was valid or not, or an empty string if there's none.
+func ConsentStringFromContext(ctx context.Context) string {
+	value, _ := ctx.Value(consentStringKey).(string)
+	return value
+}
+
+// NewErrorContext returns a copy of the context carrying the error found parsing its
+// consent string.
+func NewErrorContext(ctx context.Context, err error) context.Context {
+	return context.WithValue(ctx, errorKey, err)
+}
+
+// ErrorFromContext returns the error found parsing the consent string stored in the
+// context, if any.
+func ErrorFromContext(ctx context.Context) error {
+	err, _ := ctx.Value(errorKey).(error)
+	return err
+}
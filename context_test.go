@@ -0,0 +1,35 @@
+package iab_tcf_test
+
+import (
+	"context"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Context", func() {
+
+	It("carries the consent", func() {
+		consent, err := iab_tcf.NewConsent("COyt4MbOyt4MbMOAAAENAiCgAIAAAAAAAAAAADEAAgIAAAAAAAA")
+		Expect(err).NotTo(HaveOccurred())
+		found, ok := iab_tcf.FromContext(iab_tcf.NewContext(context.Background(), consent))
+		Expect(ok).To(BeTrue())
+		Expect(found).To(Equal(consent))
+	})
+
+	It("returns nothing when there's no consent", func() {
+		found, ok := iab_tcf.FromContext(context.Background())
+		Expect(ok).To(BeFalse())
+		Expect(found).To(BeNil())
+		Expect(iab_tcf.ConsentStringFromContext(context.Background())).To(BeEmpty())
+		Expect(iab_tcf.ErrorFromContext(context.Background())).To(BeNil())
+	})
+
+	It("carries the raw consent string and its error", func() {
+		ctx := iab_tcf.NewConsentStringContext(context.Background(), "invalid")
+		ctx = iab_tcf.NewErrorContext(ctx, iab_tcf.ErrInvalidVersion)
+		Expect(iab_tcf.ConsentStringFromContext(ctx)).To(Equal("invalid"))
+		Expect(iab_tcf.ErrorFromContext(ctx)).To(MatchError(iab_tcf.ErrInvalidVersion))
+	})
+})
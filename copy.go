@@ -0,0 +1,67 @@
+package iab_tcf
+
+import (
+	"maps"
+
+	"github.com/LiveRamp/iabconsent"
+)
+
+// The copies returned to the callers keep the consents immutable, as the iabconsent
+// types are made of maps, slices and pointers that could be modified otherwise.
+
+// copyRangeEntries returns a deep copy of the range entries.
+func copyRangeEntries(entries []*iabconsent.RangeEntry) []*iabconsent.RangeEntry {
+	if entries == nil {
+		return nil
+	}
+	result := make([]*iabconsent.RangeEntry, len(entries))
+	for i, entry := range entries {
+		copied := *entry
+		result[i] = &copied
+	}
+	return result
+}
+
+// copyRestrictions returns a deep copy of the publisher restrictions.
+func copyRestrictions(restrictions []*iabconsent.PubRestrictionEntry) []*iabconsent.PubRestrictionEntry {
+	result := make([]*iabconsent.PubRestrictionEntry, len(restrictions))
+	for i, restriction := range restrictions {
+		copied := *restriction
+		copied.RestrictionsRange = copyRangeEntries(restriction.RestrictionsRange)
+		result[i] = &copied
+	}
+	return result
+}
+
+// copyVendorList returns a deep copy of the vendor list of a segment, if any.
+func copyVendorList(vendors *iabconsent.OOBVendorList) *iabconsent.OOBVendorList {
+	if vendors == nil {
+		return nil
+	}
+	copied := *vendors
+	copied.Vendors = maps.Clone(vendors.Vendors)
+	copied.VendorEntries = copyRangeEntries(vendors.VendorEntries)
+	return &copied
+}
+
+// copyPublisherTC returns a deep copy of the publisher purposes segment, if any.
+func copyPublisherTC(entry *iabconsent.PublisherTCEntry) *iabconsent.PublisherTCEntry {
+	if entry == nil {
+		return nil
+	}
+	copied := *entry
+	copied.PubPurposesConsent = maps.Clone(entry.PubPurposesConsent)
+	copied.PubPurposesLITransparency = maps.Clone(entry.PubPurposesLITransparency)
+	copied.CustomPurposesConsent = maps.Clone(entry.CustomPurposesConsent)
+	copied.CustomPurposesLITransparency = maps.Clone(entry.CustomPurposesLITransparency)
+	return &copied
+}
+
+// copyParsedConsentV1 returns a deep copy of the TCF 1.0 parsed consent.
+func copyParsedConsentV1(p *iabconsent.ParsedConsent) *iabconsent.ParsedConsent {
+	copied := *p
+	copied.PurposesAllowed = maps.Clone(p.PurposesAllowed)
+	copied.ConsentedVendors = maps.Clone(p.ConsentedVendors)
+	copied.RangeEntries = copyRangeEntries(p.RangeEntries)
+	return &copied
+}
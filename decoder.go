@@ -0,0 +1,70 @@
+package iab_tcf
+
+import (
+	"github.com/LiveRamp/iabconsent"
+)
+
+// defaultDecoder is the decoder used by NewConsent without options.
+var defaultDecoder = &Decoder{}
+
+// Option is the type used to configure the decoding of consent strings.
+type Option func(decoder *Decoder)
+
+// Decoder decodes consent strings with the same configuration.
+type Decoder struct {
+	// Lazy decodes only the header of TCF 2.0 consent strings upfront, leaving the vendor
+	// sections, the publisher restrictions and the segments for their first access.
+	Lazy bool
+}
+
+// WithLazyDecoding decodes only the header of TCF 2.0 consent strings upfront (CMP,
+// purposes and special features among others) and the rest on first access, cutting
+// the latency when only a few vendors are checked. TCF 1.0 consent strings are always
+// fully decoded.
+//
+// As the errors in the vendor sections are found on first access, the consents fail
+// closed instead: no vendor has consent or legitimate interest, the vendor bitstrings
+// are empty and there are no publisher restrictions, while the header keeps its values.
+// ConsentErr returns the error, to tell those consents from the ones without vendors.
+func WithLazyDecoding() Option {
+	return func(decoder *Decoder) {
+		decoder.Lazy = true
+	}
+}
+
+// NewDecoder returns a decoder with the options received.
+func NewDecoder(options ...Option) *Decoder {
+	decoder := &Decoder{}
+	for _, option := range options {
+		option(decoder)
+	}
+	return decoder
+}
+
+// Decode returns a Consent instance with all the necessary information
+// available. It returns an error if something went wrong.
+func (d *Decoder) Decode(consent string) (Consent, error) {
+	decoded, err := DecodeConsent(consent)
+	if err != nil {
+		return nil, err
+	}
+	r := &bitReader{data: decoded}
+	switch iabconsent.TCFVersion(r.readInt(6)) {
+	case iabconsent.V1:
+		reader := iabconsent.NewConsentReader(decoded)
+		GetVersion(reader)
+		parsed, err := NewConsentV1(reader)
+		if err != nil {
+			return nil, err
+		}
+		parsed.(*ConsentV1).consentString = consent
+		return parsed, nil
+	case iabconsent.V2:
+		parsed, err := decodeConsentV2(r, consent, d.Lazy)
+		if err != nil {
+			return nil, err
+		}
+		return parsed, nil
+	}
+	return nil, ErrInvalidVersion
+}
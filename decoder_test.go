@@ -0,0 +1,101 @@
+package iab_tcf_test
+
+import (
+	"sync"
+	"testing"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Decoder", func() {
+
+	const (
+		testGdprConsent      = "COytyllOytyllCrAAAENAiCMAFVAACqAAAAAF3QAgAFABkAAoioAAA.IF5EX2S5OI2tho2YdF7BEYYwfJxyigMgShgQIsS8NwIeFbBoGPmAAHBG4JAQAGBAkkACBAQIsHGBcCQABgIgRiRCMQEGMjzNKBJBAggkbI0FACCVmnkHS3ZCY70-6u__bA"
+		testRestricted       = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"
+		testTruncatedVendors = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAho"
+	)
+
+	It("is configured with options", func() {
+		Expect(iab_tcf.NewDecoder().Lazy).To(BeFalse())
+		Expect(iab_tcf.NewDecoder(iab_tcf.WithLazyDecoding()).Lazy).To(BeTrue())
+	})
+
+	DescribeTable("decodes lazily the same values",
+		func(value string) {
+			eager, err := iab_tcf.NewConsent(value)
+			Expect(err).NotTo(HaveOccurred())
+			lazy, err := iab_tcf.NewConsent(value, iab_tcf.WithLazyDecoding())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lazy.CMPID()).To(Equal(eager.CMPID()))
+			Expect(lazy.GetConsentPurposeBitstring()).To(Equal(eager.GetConsentPurposeBitstring()))
+			Expect(lazy.GetConsentBitstring()).To(Equal(eager.GetConsentBitstring()))
+			Expect(lazy.GetInterestsBitstring()).To(Equal(eager.GetInterestsBitstring()))
+			Expect(lazy.GetPublisherRestrictions()).To(Equal(eager.GetPublisherRestrictions()))
+			Expect(lazy.(*iab_tcf.ConsentV2).ToParsedConsent()).To(Equal(eager.(*iab_tcf.ConsentV2).ToParsedConsent()))
+			Expect(lazy.(*iab_tcf.ConsentV2).Err()).NotTo(HaveOccurred())
+		},
+		Entry("with ranges and a disclosed vendors segment", testGdprConsent),
+		Entry("with publisher restrictions", testRestricted),
+	)
+
+	It("decodes TCF 1.0 consent strings eagerly", func() {
+		consent, err := iab_tcf.NewConsent("BOlLbqtOlLbqtAVABADECg-AAAApp7v______9______9uz_Ov_v_f__33e8__9v_l_7_-___u_-3zd4u_1vf99yfm1-7etr3tp_87ues2_Xur__79__3z3_9phP78k89r7337Ew-v02", iab_tcf.WithLazyDecoding())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.Version()).To(Equal(1))
+	})
+
+	It("reports the errors of the vendor sections on first access", func() {
+		_, err := iab_tcf.NewConsent(testTruncatedVendors)
+		Expect(err).To(MatchError(iab_tcf.ErrUnexpectedEnd))
+
+		consent, err := iab_tcf.NewConsent(testTruncatedVendors, iab_tcf.WithLazyDecoding())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.CMPID()).To(Equal(10))
+		Expect(consent.HasConsentedPurpose(1)).To(BeTrue())
+		Expect(consent.HasUserConsented(5)).To(BeFalse())
+		Expect(consent.GetConsentBitstring()).To(BeEmpty())
+		Expect(consent.GetPublisherRestrictions()).To(BeEmpty())
+		Expect(consent.(*iab_tcf.ConsentV2).Err()).To(MatchError(iab_tcf.ErrUnexpectedEnd))
+		Expect(iab_tcf.ConsentErr(consent)).To(MatchError(iab_tcf.ErrUnexpectedEnd))
+	})
+
+	It("reports no error for the consents decoded without errors", func() {
+		lazy, err := iab_tcf.NewConsent(testRestricted, iab_tcf.WithLazyDecoding())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iab_tcf.ConsentErr(lazy)).NotTo(HaveOccurred())
+		v1, err := iab_tcf.NewConsent("BOlLbqtOlLbqtAVABADECg-AAAApp7v______9______9uz_Ov_v_f__33e8__9v_l_7_-___u_-3zd4u_1vf99yfm1-7etr3tp_87ues2_Xur__79__3z3_9phP78k89r7337Ew-v02")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iab_tcf.ConsentErr(v1)).NotTo(HaveOccurred())
+	})
+
+	It("returns an error if the header is invalid", func() {
+		_, err := iab_tcf.NewConsent("CN-EdYAN-EdYAAKA", iab_tcf.WithLazyDecoding())
+		Expect(err).To(MatchError(iab_tcf.ErrUnexpectedEnd))
+	})
+
+	It("decodes the vendor sections once when accessed concurrently", func() {
+		consent, err := iab_tcf.NewConsent(testRestricted, iab_tcf.WithLazyDecoding())
+		Expect(err).NotTo(HaveOccurred())
+		wg := sync.WaitGroup{}
+		results := make([]bool, 10)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i] = consent.HasUserConsented(5) && len(consent.GetPublisherRestrictions()) == 1
+			}(i)
+		}
+		wg.Wait()
+		Expect(results).NotTo(ContainElement(false))
+	})
+
+	It("allocates less when only the header is needed", func() {
+		allocations := testing.AllocsPerRun(100, func() {
+			consent, _ := iab_tcf.NewConsent(testRestricted, iab_tcf.WithLazyDecoding())
+			consent.CMPID()
+		})
+		Expect(allocations).To(BeNumerically("<=", 3))
+	})
+})
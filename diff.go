@@ -0,0 +1,233 @@
+package iab_tcf
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/LiveRamp/iabconsent"
+)
+
+const (
+	maxPurposeID        = 24
+	maxSpecialFeatureID = 12
+)
+
+// metadataFields are the names of the metadata compared by Diff, in the order they are reported.
+var metadataFields = []string{
+	"Version",
+	"CMPID",
+	"CMPVersion",
+	"ConsentScreen",
+	"ConsentLanguage",
+	"VendorListVersion",
+	"TCFPolicyVersion",
+	"IsServiceSpecific",
+	"UseNonStandardStacks",
+	"PurposeOneTreatment",
+	"PublisherCC",
+	"Created",
+	"LastUpdated",
+}
+
+// IDChanges contains the IDs added and removed between two consents, sorted.
+type IDChanges struct {
+	Added   []int
+	Removed []int
+}
+
+// IsEmpty returns true if no ID was added or removed.
+func (c IDChanges) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0
+}
+
+// MetadataChange is a metadata field whose value changed between two consents.
+type MetadataChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// Restriction is a publisher restriction of a purpose for a range of vendors, from
+// StartVendorID to EndVendorID, both included.
+type Restriction struct {
+	PurposeID       int
+	RestrictionType iabconsent.RestrictionType
+	StartVendorID   int
+	EndVendorID     int
+}
+
+// restrictionKey identifies the publisher restrictions of a type for a purpose.
+type restrictionKey struct {
+	purposeID       int
+	restrictionType iabconsent.RestrictionType
+}
+
+// ConsentDiff contains every change between two consents.
+type ConsentDiff struct {
+	Metadata                   []MetadataChange
+	PurposeConsents            IDChanges
+	PurposeLegitimateInterests IDChanges
+	SpecialFeatureOptIns       IDChanges
+	VendorConsents             IDChanges
+	VendorLegitimateInterests  IDChanges
+	AddedRestrictions          []Restriction
+	RemovedRestrictions        []Restriction
+}
+
+// IsEmpty returns true if both consents contain the same choices and metadata.
+func (d *ConsentDiff) IsEmpty() bool {
+	return len(d.Metadata) == 0 &&
+		d.PurposeConsents.IsEmpty() &&
+		d.PurposeLegitimateInterests.IsEmpty() &&
+		d.SpecialFeatureOptIns.IsEmpty() &&
+		d.VendorConsents.IsEmpty() &&
+		d.VendorLegitimateInterests.IsEmpty() &&
+		len(d.AddedRestrictions) == 0 &&
+		len(d.RemovedRestrictions) == 0
+}
+
+// Diff returns the changes needed to go from the consent a to the consent b: the metadata
+// changed, the purposes, special features and vendors with consent or legitimate interest
+// added and removed, and the publisher restrictions added and removed.
+// TCF 1.0 consents have no legitimate interests, so they aren't compared when any of the
+// consents is a TCF 1.0 one.
+func Diff(a, b Consent) *ConsentDiff {
+	consentsA, interestsA := maxVendorIDs(a)
+	consentsB, interestsB := maxVendorIDs(b)
+	d := &ConsentDiff{
+		PurposeConsents:            diffIDs(maxPurposeID, maxPurposeID, a.HasConsentedPurpose, b.HasConsentedPurpose),
+		PurposeLegitimateInterests: IDChanges{Added: []int{}, Removed: []int{}},
+		SpecialFeatureOptIns:       diffIDs(maxSpecialFeatureID, maxSpecialFeatureID, specialFeatureOptIn(a), specialFeatureOptIn(b)),
+		VendorConsents:             diffIDs(consentsA, consentsB, a.HasUserConsented, b.HasUserConsented),
+		VendorLegitimateInterests:  IDChanges{Added: []int{}, Removed: []int{}},
+		Metadata:                   []MetadataChange{},
+	}
+	if a.Version() != 1 && b.Version() != 1 {
+		d.PurposeLegitimateInterests = diffIDs(maxPurposeID, maxPurposeID, a.HasConsentedLegitimateInterestForPurpose, b.HasConsentedLegitimateInterestForPurpose)
+		d.VendorLegitimateInterests = diffIDs(interestsA, interestsB, a.HasUserLegitimateInterest, b.HasUserLegitimateInterest)
+	}
+	from, to := metadata(a), metadata(b)
+	for _, field := range metadataFields {
+		if from[field] != to[field] {
+			d.Metadata = append(d.Metadata, MetadataChange{Field: field, From: from[field], To: to[field]})
+		}
+	}
+	fromRestrictions, toRestrictions := restrictions(a), restrictions(b)
+	d.AddedRestrictions = subtractRestrictions(toRestrictions, fromRestrictions)
+	d.RemovedRestrictions = subtractRestrictions(fromRestrictions, toRestrictions)
+	return d
+}
+
+// diffIDs returns the IDs set in b but not in a as added, and the ones set in a but not
+// in b as removed, checking every ID up to the greatest of the maximums received.
+func diffIDs(maxA, maxB int, a, b func(id int) bool) IDChanges {
+	changes := IDChanges{Added: []int{}, Removed: []int{}}
+	for id := 1; id <= max(maxA, maxB); id++ {
+		inA, inB := id <= maxA && a(id), id <= maxB && b(id)
+		switch {
+		case inB && !inA:
+			changes.Added = append(changes.Added, id)
+		case inA && !inB:
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+	return changes
+}
+
+// maxVendorIDs returns the greatest vendor IDs of the vendor consents and legitimate
+// interests of the consent, from the lengths of its bitstrings if it's not a ConsentV1 or
+// a ConsentV2.
+func maxVendorIDs(consent Consent) (consents, interests int) {
+	switch c := consent.(type) {
+	case *ConsentV1:
+		return c.MaxVendorID(), 0
+	case *ConsentV2:
+		return c.MaxConsentVendorID(), c.MaxInterestsVendorID()
+	}
+	return len(consent.GetConsentBitstring()), len(consent.GetInterestsBitstring())
+}
+
+// specialFeatureOptIn returns the opt-ins of the Special Features of the consent, none if
+// it doesn't implement SpecialFeatureConsent.
+func specialFeatureOptIn(consent Consent) func(featureID int) bool {
+	if features, ok := consent.(SpecialFeatureConsent); ok {
+		return features.HasSpecialFeatureOptIn
+	}
+	return func(int) bool { return false }
+}
+
+// metadata returns the metadata fields of the consent formatted as strings.
+func metadata(consent Consent) map[string]string {
+	values := map[string]string{
+		"Version": strconv.Itoa(consent.Version()),
+		"CMPID":   strconv.Itoa(consent.CMPID()),
+	}
+	switch c := consent.(type) {
+	case *ConsentV1:
+		values["CMPVersion"] = strconv.Itoa(c.CMPVersion())
+		values["ConsentScreen"] = strconv.Itoa(c.ConsentScreen())
+		values["ConsentLanguage"] = c.ConsentLanguage()
+		values["VendorListVersion"] = strconv.Itoa(c.VendorListVersion())
+		values["Created"] = c.Created().Format(time.RFC3339Nano)
+		values["LastUpdated"] = c.LastUpdated().Format(time.RFC3339Nano)
+	case *ConsentV2:
+		values["CMPVersion"] = strconv.Itoa(c.CMPVersion())
+		values["ConsentScreen"] = strconv.Itoa(c.ConsentScreen())
+		values["ConsentLanguage"] = c.ConsentLanguage()
+		values["VendorListVersion"] = strconv.Itoa(c.VendorListVersion())
+		values["TCFPolicyVersion"] = strconv.Itoa(c.TCFPolicyVersion())
+		values["IsServiceSpecific"] = strconv.FormatBool(c.IsServiceSpecific())
+		values["UseNonStandardStacks"] = strconv.FormatBool(c.UseNonStandardStacks())
+		values["PurposeOneTreatment"] = strconv.FormatBool(c.PurposeOneTreatment())
+		values["PublisherCC"] = c.PublisherCC()
+		values["Created"] = c.Created().Format(time.RFC3339Nano)
+		values["LastUpdated"] = c.LastUpdated().Format(time.RFC3339Nano)
+	}
+	return values
+}
+
+// restrictions returns the vendors of the publisher restrictions of the consent, as a
+// bitset per purpose and restriction type.
+func restrictions(consent Consent) map[restrictionKey]bitset {
+	result := map[restrictionKey]bitset{}
+	for _, entry := range consent.GetPublisherRestrictions() {
+		key := restrictionKey{purposeID: entry.PurposeID, restrictionType: entry.RestrictionType}
+		vendors := result[key]
+		for _, vendorRange := range entry.RestrictionsRange {
+			vendors.setRange(vendorRange.StartVendorID, vendorRange.EndVendorID)
+		}
+		result[key] = vendors
+	}
+	return result
+}
+
+// subtractRestrictions returns the ranges of vendors of the restrictions of a that are
+// not in b, sorted by purpose, restriction type and vendor.
+func subtractRestrictions(a, b map[restrictionKey]bitset) []Restriction {
+	keys := slices.SortedFunc(maps.Keys(a), func(x, y restrictionKey) int {
+		if x.purposeID != y.purposeID {
+			return x.purposeID - y.purposeID
+		}
+		return int(x.restrictionType) - int(y.restrictionType)
+	})
+	result := []Restriction{}
+	for _, key := range keys {
+		vendors := slices.Clone(a[key])
+		for i, word := range b[key] {
+			if i < len(vendors) {
+				vendors[i] &^= word
+			}
+		}
+		for _, entry := range vendors.ranges() {
+			result = append(result, Restriction{
+				PurposeID:       key.purposeID,
+				RestrictionType: key.restrictionType,
+				StartVendorID:   entry.StartVendorID,
+				EndVendorID:     entry.EndVendorID,
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,138 @@
+package iab_tcf_test
+
+import (
+	"time"
+
+	"github.com/LiveRamp/iabconsent"
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+
+	const testGdprConsent = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"
+
+	var a, b iab_tcf.Consent
+
+	BeforeEach(func() {
+		var err error
+		a, err = iab_tcf.NewConsent(testGdprConsent)
+		Expect(err).NotTo(HaveOccurred())
+		parsedConsent := *a.(*iab_tcf.ConsentV2).ToParsedConsent()
+		parsedConsent.CMPID = 12
+		parsedConsent.VendorListVersion = 51
+		parsedConsent.LastUpdated = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		parsedConsent.PurposesConsent = map[int]bool{1: true, 2: true, 5: true}
+		parsedConsent.SpecialFeaturesOptIn = map[int]bool{2: true}
+		parsedConsent.MaxConsentVendorID = 12
+		parsedConsent.ConsentedVendors = map[int]bool{6: true, 12: true}
+		parsedConsent.PubRestrictionEntries = []*iabconsent.PubRestrictionEntry{{
+			PurposeID:         2,
+			RestrictionType:   iabconsent.RequireConsent,
+			RestrictionsRange: []*iabconsent.RangeEntry{{StartVendorID: 7, EndVendorID: 8}},
+		}}
+		encoded, err := iab_tcf.EncodeV2(&parsedConsent)
+		Expect(err).NotTo(HaveOccurred())
+		b, err = iab_tcf.NewConsent(encoded)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("reports nothing for the same consent", func() {
+		Expect(iab_tcf.Diff(a, a).IsEmpty()).To(BeTrue())
+	})
+
+	It("reports the metadata changes", func() {
+		Expect(iab_tcf.Diff(a, b).Metadata).To(Equal([]iab_tcf.MetadataChange{
+			{Field: "CMPID", From: "10", To: "12"},
+			{Field: "VendorListVersion", From: "50", To: "51"},
+			{Field: "LastUpdated", From: "2017-07-14T02:40:00Z", To: "2020-01-02T03:04:05Z"},
+		}))
+	})
+
+	It("reports the purpose and special feature changes", func() {
+		d := iab_tcf.Diff(a, b)
+		Expect(d.PurposeConsents).To(Equal(iab_tcf.IDChanges{Added: []int{5}, Removed: []int{3, 4}}))
+		Expect(d.PurposeLegitimateInterests.IsEmpty()).To(BeTrue())
+		Expect(d.SpecialFeatureOptIns).To(Equal(iab_tcf.IDChanges{Added: []int{2}, Removed: []int{1}}))
+	})
+
+	It("reports the vendor changes", func() {
+		d := iab_tcf.Diff(a, b)
+		Expect(d.VendorConsents).To(Equal(iab_tcf.IDChanges{Added: []int{6, 12}, Removed: []int{5}}))
+		Expect(d.VendorLegitimateInterests.IsEmpty()).To(BeTrue())
+	})
+
+	It("reports the restriction changes", func() {
+		d := iab_tcf.Diff(a, b)
+		Expect(d.AddedRestrictions).To(Equal([]iab_tcf.Restriction{
+			{PurposeID: 2, RestrictionType: iabconsent.RequireConsent, StartVendorID: 7, EndVendorID: 8},
+		}))
+		Expect(d.RemovedRestrictions).To(Equal([]iab_tcf.Restriction{
+			{PurposeID: 1, RestrictionType: iabconsent.PurposeFlatlyNotAllowed, StartVendorID: 5, EndVendorID: 5},
+		}))
+	})
+
+	It("reports the restriction changes as ranges of vendors", func() {
+		parsedConsent := *a.(*iab_tcf.ConsentV2).ToParsedConsent()
+		parsedConsent.PubRestrictionEntries = []*iabconsent.PubRestrictionEntry{{
+			PurposeID:       1,
+			RestrictionType: iabconsent.PurposeFlatlyNotAllowed,
+			RestrictionsRange: []*iabconsent.RangeEntry{
+				{StartVendorID: 1, EndVendorID: 63},
+				{StartVendorID: 60, EndVendorID: 200},
+				{StartVendorID: 300, EndVendorID: 300},
+			},
+		}}
+		encoded, err := iab_tcf.EncodeV2(&parsedConsent)
+		Expect(err).NotTo(HaveOccurred())
+		c, err := iab_tcf.NewConsent(encoded)
+		Expect(err).NotTo(HaveOccurred())
+		d := iab_tcf.Diff(a, c)
+		Expect(d.AddedRestrictions).To(Equal([]iab_tcf.Restriction{
+			{PurposeID: 1, RestrictionType: iabconsent.PurposeFlatlyNotAllowed, StartVendorID: 1, EndVendorID: 4},
+			{PurposeID: 1, RestrictionType: iabconsent.PurposeFlatlyNotAllowed, StartVendorID: 6, EndVendorID: 200},
+			{PurposeID: 1, RestrictionType: iabconsent.PurposeFlatlyNotAllowed, StartVendorID: 300, EndVendorID: 300},
+		}))
+		Expect(d.RemovedRestrictions).To(BeEmpty())
+	})
+
+	It("reports the use of non-standard stacks", func() {
+		parsedConsent := *a.(*iab_tcf.ConsentV2).ToParsedConsent()
+		parsedConsent.UseNonStandardStacks = true
+		encoded, err := iab_tcf.EncodeV2(&parsedConsent)
+		Expect(err).NotTo(HaveOccurred())
+		c, err := iab_tcf.NewConsent(encoded)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iab_tcf.Diff(a, c).Metadata).To(Equal([]iab_tcf.MetadataChange{
+			{Field: "UseNonStandardStacks", From: "false", To: "true"},
+		}))
+	})
+
+	It("compares the vendors up to the maximum vendor IDs", func() {
+		parsedConsent := *a.(*iab_tcf.ConsentV2).ToParsedConsent()
+		parsedConsent.MaxInterestsVendorID = 20
+		parsedConsent.InterestsVendors = map[int]bool{20: true}
+		encoded, err := iab_tcf.EncodeV2(&parsedConsent)
+		Expect(err).NotTo(HaveOccurred())
+		c, err := iab_tcf.NewConsent(encoded)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iab_tcf.Diff(a, c).VendorLegitimateInterests).To(Equal(iab_tcf.IDChanges{Added: []int{20}, Removed: []int{}}))
+	})
+
+	It("doesn't compare the legitimate interests of TCF 1.0 consents", func() {
+		v1, err := iab_tcf.NewConsent("BOlLbqtOlLbqtAVABADECg-AAAApp7v______9______9uz_Ov_v_f__33e8__9v_l_7_-___u_-33d4-_1vf99yfm1-7ftr3tp_87ues2_Xur__79__3z3_9phP78k89r7337Ew-v02")
+		Expect(err).NotTo(HaveOccurred())
+		for _, d := range []*iab_tcf.ConsentDiff{iab_tcf.Diff(v1, a), iab_tcf.Diff(a, v1)} {
+			Expect(d.PurposeLegitimateInterests.IsEmpty()).To(BeTrue())
+			Expect(d.VendorLegitimateInterests.IsEmpty()).To(BeTrue())
+			Expect(d.VendorConsents.IsEmpty()).To(BeFalse())
+		}
+	})
+
+	It("reverses the changes when swapping the consents", func() {
+		d := iab_tcf.Diff(b, a)
+		Expect(d.VendorConsents).To(Equal(iab_tcf.IDChanges{Added: []int{5}, Removed: []int{6, 12}}))
+		Expect(d.Metadata[0]).To(Equal(iab_tcf.MetadataChange{Field: "CMPID", From: "12", To: "10"}))
+	})
+})
@@ -0,0 +1,207 @@
+package iab_tcf
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LiveRamp/iabconsent"
+)
+
+// ErrValueOutOfRange is returned when a value doesn't fit in the bits reserved for it.
+var ErrValueOutOfRange = errors.New("Value out of range")
+
+// bitWriter is the counterpart of the iabconsent.ConsentReader, appending values bit by bit.
+type bitWriter struct {
+	data []byte
+	size uint
+	err  error
+}
+
+// writeInt appends the value using n bits.
+func (w *bitWriter) writeInt(value int, n uint, name string) {
+	if w.err != nil {
+		return
+	}
+	if value < 0 || uint64(value) >= 1<<n {
+		w.err = fmt.Errorf("%w: %s %d doesn't fit in %d bits", ErrValueOutOfRange, name, value, n)
+		return
+	}
+	for i := int(n) - 1; i >= 0; i-- {
+		w.writeBool(value&(1<<i) != 0)
+	}
+}
+
+// writeBool appends a single bit.
+func (w *bitWriter) writeBool(value bool) {
+	if w.size%8 == 0 {
+		w.data = append(w.data, 0)
+	}
+	if value {
+		w.data[w.size/8] |= 1 << (7 - w.size%8)
+	}
+	w.size++
+}
+
+// writeTime appends the time as deciseconds using 36 bits, or zero if it's not set.
+func (w *bitWriter) writeTime(value time.Time, name string) {
+	if value.IsZero() {
+		w.writeInt(0, 36, name)
+		return
+	}
+	w.writeInt(int(value.UnixNano()/int64(100*time.Millisecond)), 36, name)
+}
+
+// writeString appends the two uppercase letters of the value using 6 bits each.
+func (w *bitWriter) writeString(value string, name string) {
+	if err := checkLetters(value, name); err != nil {
+		if w.err == nil {
+			w.err = err
+		}
+		return
+	}
+	for _, letter := range []byte(value) {
+		w.writeInt(int(letter)-'A', 6, name)
+	}
+}
+
+// writeBitField appends a bit for each one of the IDs from 1 to n.
+func (w *bitWriter) writeBitField(values map[int]bool, n int) {
+	for i := 1; i <= n; i++ {
+		w.writeBool(values[i])
+	}
+}
+
+// writeRangeEntries appends the range entries, without their number, failing with
+// ErrInvalidRange if any of them ends before its start or past maxVendorID.
+func (w *bitWriter) writeRangeEntries(entries []*iabconsent.RangeEntry, maxVendorID int) {
+	for _, entry := range entries {
+		if err := checkRange(entry.StartVendorID, entry.EndVendorID, maxVendorID); err != nil {
+			if w.err == nil {
+				w.err = err
+			}
+			return
+		}
+		isRange := entry.EndVendorID != entry.StartVendorID
+		w.writeBool(isRange)
+		w.writeInt(entry.StartVendorID, 16, "range start vendor ID")
+		if isRange {
+			w.writeInt(entry.EndVendorID, 16, "range end vendor ID")
+		}
+	}
+}
+
+// writeVendors appends a vendor list, either as a bit field or as range entries.
+func (w *bitWriter) writeVendors(maxVendorID int, isRange bool, vendors map[int]bool, entries []*iabconsent.RangeEntry) {
+	w.writeInt(maxVendorID, 16, "max vendor ID")
+	w.writeBool(isRange)
+	if isRange {
+		w.writeInt(len(entries), 12, "number of range entries")
+		w.writeRangeEntries(entries, maxVendorID)
+	} else {
+		w.writeBitField(vendors, maxVendorID)
+	}
+}
+
+// encode returns the bits written encoded as a consent string segment.
+func (w *bitWriter) encode() (string, error) {
+	if w.err != nil {
+		return "", w.err
+	}
+	return base64.RawURLEncoding.EncodeToString(w.data), nil
+}
+
+// EncodeV1 returns the TCF 1.0 version consent string of the parsed consent received.
+// The number of range entries is taken from the entries themselves.
+func EncodeV1(p *iabconsent.ParsedConsent) (string, error) {
+	w := &bitWriter{}
+	w.writeInt(int(iabconsent.V1), 6, "version")
+	w.writeTime(p.Created, "created")
+	w.writeTime(p.LastUpdated, "last updated")
+	w.writeInt(p.CMPID, 12, "CMP ID")
+	w.writeInt(p.CMPVersion, 12, "CMP version")
+	w.writeInt(p.ConsentScreen, 6, "consent screen")
+	w.writeString(p.ConsentLanguage, "consent language")
+	w.writeInt(p.VendorListVersion, 12, "vendor list version")
+	w.writeBitField(p.PurposesAllowed, 24)
+	w.writeInt(p.MaxVendorID, 16, "max vendor ID")
+	w.writeBool(p.IsRangeEncoding)
+	if p.IsRangeEncoding {
+		w.writeBool(p.DefaultConsent)
+		w.writeInt(len(p.RangeEntries), 12, "number of range entries")
+		w.writeRangeEntries(p.RangeEntries, p.MaxVendorID)
+	} else {
+		w.writeBitField(p.ConsentedVendors, p.MaxVendorID)
+	}
+	return w.encode()
+}
+
+// EncodeV2 returns the TCF 2.0 version consent string of the parsed consent received,
+// including the disclosed vendors, allowed vendors and publisher purposes segments if
+// present. The number of entries of every list is taken from the entries themselves.
+func EncodeV2(p *iabconsent.V2ParsedConsent) (string, error) {
+	w := &bitWriter{}
+	w.writeInt(int(iabconsent.V2), 6, "version")
+	w.writeTime(p.Created, "created")
+	w.writeTime(p.LastUpdated, "last updated")
+	w.writeInt(p.CMPID, 12, "CMP ID")
+	w.writeInt(p.CMPVersion, 12, "CMP version")
+	w.writeInt(p.ConsentScreen, 6, "consent screen")
+	w.writeString(p.ConsentLanguage, "consent language")
+	w.writeInt(p.VendorListVersion, 12, "vendor list version")
+	w.writeInt(p.TCFPolicyVersion, 6, "TCF policy version")
+	w.writeBool(p.IsServiceSpecific)
+	w.writeBool(p.UseNonStandardStacks)
+	w.writeBitField(p.SpecialFeaturesOptIn, 12)
+	w.writeBitField(p.PurposesConsent, 24)
+	w.writeBitField(p.PurposesLITransparency, 24)
+	w.writeBool(p.PurposeOneTreatment)
+	w.writeString(p.PublisherCC, "publisher country code")
+	w.writeVendors(p.MaxConsentVendorID, p.IsConsentRangeEncoding, p.ConsentedVendors, p.ConsentedVendorsRange)
+	w.writeVendors(p.MaxInterestsVendorID, p.IsInterestsRangeEncoding, p.InterestsVendors, p.InterestsVendorsRange)
+	w.writeInt(len(p.PubRestrictionEntries), 12, "number of publisher restrictions")
+	for _, restriction := range p.PubRestrictionEntries {
+		w.writeInt(restriction.PurposeID, 6, "restriction purpose ID")
+		w.writeInt(int(restriction.RestrictionType), 2, "restriction type")
+		w.writeInt(len(restriction.RestrictionsRange), 12, "number of restriction entries")
+		w.writeRangeEntries(restriction.RestrictionsRange, maxVendorIDLimit)
+	}
+	consent, err := w.encode()
+	if err != nil {
+		return "", err
+	}
+	segments := map[iabconsent.SegmentType]*iabconsent.OOBVendorList{
+		iabconsent.DisclosedVendors: p.OOBDisclosedVendors,
+		iabconsent.AllowedVendors:   p.OOBAllowedVendors,
+	}
+	for _, segmentType := range []iabconsent.SegmentType{iabconsent.DisclosedVendors, iabconsent.AllowedVendors} {
+		vendors := segments[segmentType]
+		if vendors == nil {
+			continue
+		}
+		w = &bitWriter{}
+		w.writeInt(int(segmentType), 3, "segment type")
+		w.writeVendors(vendors.MaxVendorID, vendors.IsRangeEncoding, vendors.Vendors, vendors.VendorEntries)
+		segment, err := w.encode()
+		if err != nil {
+			return "", err
+		}
+		consent += "." + segment
+	}
+	if entry := p.PublisherTCEntry; entry != nil {
+		w = &bitWriter{}
+		w.writeInt(int(iabconsent.PublisherTC), 3, "segment type")
+		w.writeBitField(entry.PubPurposesConsent, 24)
+		w.writeBitField(entry.PubPurposesLITransparency, 24)
+		w.writeInt(entry.NumCustomPurposes, 6, "number of custom purposes")
+		w.writeBitField(entry.CustomPurposesConsent, entry.NumCustomPurposes)
+		w.writeBitField(entry.CustomPurposesLITransparency, entry.NumCustomPurposes)
+		segment, err := w.encode()
+		if err != nil {
+			return "", err
+		}
+		consent += "." + segment
+	}
+	return consent, nil
+}
@@ -0,0 +1,106 @@
+package iab_tcf_test
+
+import (
+	"errors"
+
+	"github.com/LiveRamp/iabconsent"
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encoder", func() {
+
+	DescribeTable("encodes TCF 2.0 consent strings back to the same string",
+		func(value string) {
+			consent, err := iab_tcf.NewConsent(value)
+			Expect(err).NotTo(HaveOccurred())
+			encoded, err := iab_tcf.EncodeV2(consent.(*iab_tcf.ConsentV2).ToParsedConsent())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoded).To(Equal(value))
+		},
+		Entry("with a bit field", "COxR03kOxR1CqBcABCENAgCMAP_AAH_AAAqIF3EXySoGY2thI2YVFxBEIYwfJxyigMgChgQIsSwNQIeFLBoGLiAAHBGYJAQAGBAEEACBAQIkHGBMCQAAgAgBiRCMQEGMCzNIBIBAggEbY0FACCVmHkHSmZCY7064O__QLuIJEFQMAkSBAIACLECIQwAQDiAAAYAlAAABAhIaAAgIWBQEeAAAACAwAAgAAABBAAACAAQAAICIAAABAAAgAiAQAAAAGgIQAACBABACRIAAAEANCAAgiCEAQg4EAo4AAA"),
+		Entry("with ranges and a disclosed vendors segment", "COytyllOytyllCrAAAENAiCMAFVAACqAAAAAF3QAgAFABkAAoioAAA.IF5EX2S5OI2tho2YdF7BEYYwfJxyigMgShgQIsS8NwIeFbBoGPmAAHBG4JAQAGBAkkACBAQIsHGBcCQABgIgRiRCMQEGMjzNKBJBAggkbI0FACCVmnkHS3ZCY70-6u__bA"),
+		Entry("with publisher restrictions", "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"),
+	)
+
+	It("encodes TCF 1.0 consent strings back to the same string", func() {
+		value := "BOlLbqtOlLbqtAVABADECg-AAAApp7v______9______9uz_Ov_v_f__33e8__9v_l_7_-___u_-3zd4u_1vf99yfm1-7etr3tp_87ues2_Xur__79__3z3_9phP78k89r7337Ew-v02"
+		consent, err := iab_tcf.NewConsent(value)
+		Expect(err).NotTo(HaveOccurred())
+		encoded, err := iab_tcf.EncodeV1(consent.(*iab_tcf.ConsentV1).ToParsedConsent())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(encoded).To(Equal(value))
+	})
+
+	It("encodes the publisher purposes segment", func() {
+		encoded, err := iab_tcf.EncodeV2(&iabconsent.V2ParsedConsent{
+			ConsentLanguage: "EN",
+			PublisherCC:     "ES",
+			PurposesConsent: map[int]bool{1: true},
+			PublisherTCEntry: &iabconsent.PublisherTCEntry{
+				PubPurposesConsent:    map[int]bool{2: true},
+				NumCustomPurposes:     2,
+				CustomPurposesConsent: map[int]bool{2: true},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		consent, err := iab_tcf.NewConsent(encoded)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.HasConsentedPurpose(1)).To(BeTrue())
+		entry := consent.(*iab_tcf.ConsentV2).ToParsedConsent().PublisherTCEntry
+		Expect(entry.PubPurposesConsent).To(Equal(map[int]bool{2: true}))
+		Expect(entry.CustomPurposesConsent).To(Equal(map[int]bool{2: true}))
+	})
+
+	It("fails with values out of range", func() {
+		_, err := iab_tcf.EncodeV2(&iabconsent.V2ParsedConsent{CMPID: 4096, ConsentLanguage: "EN", PublisherCC: "ES"})
+		Expect(errors.Is(err, iab_tcf.ErrValueOutOfRange)).To(BeTrue())
+		_, err = iab_tcf.EncodeV1(&iabconsent.ParsedConsent{ConsentLanguage: "english"})
+		Expect(errors.Is(err, iab_tcf.ErrValueOutOfRange)).To(BeTrue())
+	})
+
+	DescribeTable("fails with letters other than A to Z",
+		func(language string) {
+			_, err := iab_tcf.EncodeV2(&iabconsent.V2ParsedConsent{ConsentLanguage: language, PublisherCC: "ES"})
+			Expect(err).To(MatchError(iab_tcf.ErrValueOutOfRange))
+		},
+		Entry("with lowercase letters", "en"),
+		Entry("with digits", "E1"),
+		Entry("with symbols", "E["),
+	)
+
+	DescribeTable("fails with invalid range entries",
+		func(start, end int) {
+			_, err := iab_tcf.EncodeV1(&iabconsent.ParsedConsent{
+				ConsentLanguage: "EN",
+				MaxVendorID:     10,
+				IsRangeEncoding: true,
+				RangeEntries:    []*iabconsent.RangeEntry{{StartVendorID: start, EndVendorID: end}},
+			})
+			Expect(err).To(MatchError(iab_tcf.ErrInvalidRange))
+		},
+		Entry("ending before its start", 8, 3),
+		Entry("starting at 0", 0, 3),
+		Entry("ending past the maximum vendor ID", 8, 11),
+	)
+
+	It("encodes range entries up to the maximum vendor ID only, as the decoder requires", func() {
+		parsed := &iabconsent.V2ParsedConsent{
+			ConsentLanguage:        "EN",
+			PublisherCC:            "ES",
+			MaxConsentVendorID:     10,
+			IsConsentRangeEncoding: true,
+			ConsentedVendorsRange:  []*iabconsent.RangeEntry{{StartVendorID: 3, EndVendorID: 10}},
+		}
+		encoded, err := iab_tcf.EncodeV2(parsed)
+		Expect(err).NotTo(HaveOccurred())
+		consent, err := iab_tcf.NewConsent(encoded)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.HasUserConsented(10)).To(BeTrue())
+
+		parsed.ConsentedVendorsRange[0].EndVendorID = 11
+		_, err = iab_tcf.EncodeV2(parsed)
+		Expect(err).To(MatchError(iab_tcf.ErrInvalidRange))
+	})
+})
@@ -0,0 +1,78 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListSeparator separates the IDs of the list columns in CSV, e.g. `1;5;7`.
+const ListSeparator = ";"
+
+// CSVWriter writes the rows as CSV, with a header line with the names of the columns.
+// Timestamps are written as RFC 3339 and the booleans as `true` or `false`.
+type CSVWriter struct {
+	writer *csv.Writer
+	header bool
+	record []string
+}
+
+// NewCSVWriter returns a writer of the rows as CSV to w.
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{writer: csv.NewWriter(w), record: make([]string, len(columns))}
+}
+
+// Write writes the row, after the header if it's the first one.
+func (w *CSVWriter) Write(row *Row) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	for i, c := range columns {
+		w.record[i] = formatValue(c.value(row))
+	}
+	return w.writer.Write(w.record)
+}
+
+// Close flushes the rows buffered, writing the header if there weren't any.
+func (w *CSVWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// writeHeader writes the names of the columns, only once.
+func (w *CSVWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+	return w.writer.Write(Columns())
+}
+
+// formatValue returns the CSV representation of the value of a column.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case string:
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339Nano)
+	case []int:
+		values := make([]string, len(v))
+		for i, id := range v {
+			values[i] = strconv.Itoa(id)
+		}
+		return strings.Join(values, ListSeparator)
+	}
+	return ""
+}
@@ -0,0 +1,212 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"time"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/export"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/parquet-go/parquet-go"
+)
+
+var _ = Describe("Export", func() {
+
+	const (
+		testConsent   = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"
+		testV1Consent = "BOlLbqtOlLbqtAVABADECg-AAAApp7v______9______9uz_Ov_v_f__33e8__9v_l_7_-___u_-3zd4u_1vf99yfm1-7etr3tp_87ues2_Xur__79__3z3_9phP78k89r7337Ew-v02"
+	)
+
+	newRow := func(value string) *export.Row {
+		consent, err := iab_tcf.NewConsent(value)
+		Expect(err).NotTo(HaveOccurred())
+		return export.NewRow(consent)
+	}
+
+	Describe("rows", func() {
+		It("flattens TCF 2.0 consents", func() {
+			row := newRow(testConsent)
+			Expect(row.TCFVersion).To(Equal(2))
+			Expect(row.CMPID).To(Equal(10))
+			Expect(row.Created).To(Equal(time.Date(2017, 7, 14, 2, 40, 0, 0, time.UTC)))
+			Expect(row.ConsentLanguage).To(Equal("EN"))
+			Expect(row.PublisherCC).To(Equal("EN"))
+			Expect(row.PurposeConsents[:5]).To(Equal([]bool{true, true, true, true, false}))
+			Expect(row.SpecialFeatureOptIns).To(Equal([export.NumSpecialFeatures]bool{true, false}))
+			Expect(row.VendorConsents).To(Equal([]int{5}))
+			Expect(row.VendorLegitimateInterests).To(BeEmpty())
+			Expect(row.Restrictions).To(Equal([]export.Restriction{{PurposeID: 1, RestrictionType: 0, StartVendorID: 5, EndVendorID: 5}}))
+		})
+
+		It("flattens TCF 1.0 consents", func() {
+			consent, err := iab_tcf.NewConsent(testV1Consent)
+			Expect(err).NotTo(HaveOccurred())
+			row := export.NewRow(consent)
+			Expect(row.TCFVersion).To(Equal(1))
+			Expect(row.CMPID).To(Equal(consent.CMPID()))
+			Expect(row.PublisherCC).To(BeEmpty())
+			Expect(row.PurposeLegitimateInterests).To(Equal([export.NumPurposes]bool{}))
+			Expect(row.VendorConsents).NotTo(BeEmpty())
+			for _, vendorID := range row.VendorConsents {
+				Expect(consent.HasUserConsented(vendorID)).To(BeTrue())
+			}
+		})
+	})
+
+	Describe("CSV", func() {
+		It("writes a header and a line per row", func() {
+			buffer := &bytes.Buffer{}
+			w := export.NewCSVWriter(buffer)
+			Expect(w.Write(newRow(testConsent))).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+			records, err := csv.NewReader(buffer).ReadAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(2))
+			Expect(records[0]).To(Equal(export.Columns()))
+			values := map[string]string{}
+			for i, name := range records[0] {
+				values[name] = records[1][i]
+			}
+			Expect(values).To(HaveKeyWithValue("cmp_id", "10"))
+			Expect(values).To(HaveKeyWithValue("created", "2017-07-14T02:40:00Z"))
+			Expect(values).To(HaveKeyWithValue("purpose_consent_1", "true"))
+			Expect(values).To(HaveKeyWithValue("purpose_consent_5", "false"))
+			Expect(values).To(HaveKeyWithValue("vendor_consents", "5"))
+			Expect(values).To(HaveKeyWithValue("vendor_legitimate_interests", ""))
+			Expect(values).To(HaveKeyWithValue("restriction_start_vendor_ids", "5"))
+			Expect(values).To(HaveKeyWithValue("restriction_end_vendor_ids", "5"))
+		})
+
+		It("writes the header without rows", func() {
+			buffer := &bytes.Buffer{}
+			Expect(export.NewCSVWriter(buffer).Close()).To(Succeed())
+			records, err := csv.NewReader(buffer).ReadAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(Equal([][]string{export.Columns()}))
+		})
+	})
+
+	Describe("Parquet", func() {
+		var file *parquet.File
+
+		write := func(rows []*export.Row, options ...export.ParquetOption) {
+			buffer := &bytes.Buffer{}
+			w := export.NewParquetWriter(buffer, options...)
+			for _, row := range rows {
+				Expect(w.Write(row)).To(Succeed())
+			}
+			Expect(w.Close()).To(Succeed())
+			var err error
+			file, err = parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		// read returns the values of the rows of the file by column, without the nulls.
+		read := func() []map[string][]any {
+			columns := file.Schema().Columns()
+			reader := parquet.NewReader(file)
+			defer reader.Close()
+			var rows []map[string][]any
+			buffer := make([]parquet.Row, 1)
+			for {
+				n, err := reader.ReadRows(buffer)
+				if n == 1 {
+					values := map[string][]any{}
+					buffer[0].Range(func(column int, columnValues []parquet.Value) bool {
+						name := columns[column][0]
+						values[name] = []any{}
+						for _, v := range columnValues {
+							switch {
+							case v.IsNull():
+							case v.Kind() == parquet.Boolean:
+								values[name] = append(values[name], v.Boolean())
+							case v.Kind() == parquet.Int32:
+								values[name] = append(values[name], int(v.Int32()))
+							case v.Kind() == parquet.Int64:
+								values[name] = append(values[name], v.Int64())
+							default:
+								values[name] = append(values[name], string(v.ByteArray()))
+							}
+						}
+						return true
+					})
+					rows = append(rows, values)
+				}
+				if err == io.EOF {
+					return rows
+				}
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+
+		It("writes the schema of the columns", func() {
+			write([]*export.Row{newRow(testConsent)})
+			Expect(file.Schema().Name()).To(Equal("consent"))
+			names := []string{}
+			for _, field := range file.Schema().Fields() {
+				names = append(names, field.Name())
+			}
+			Expect(names).To(Equal(export.Columns()))
+			created, _ := file.Schema().Lookup("created")
+			Expect(created.Node.Optional()).To(BeTrue())
+			Expect(created.Node.Type().LogicalType().Timestamp).NotTo(BeNil())
+			language, _ := file.Schema().Lookup("consent_language")
+			Expect(language.Node.Type().LogicalType().UTF8).NotTo(BeNil())
+			vendors, _ := file.Schema().Lookup("vendor_consents")
+			Expect(vendors.Node.Repeated()).To(BeTrue())
+			Expect(vendors.Node.Type().Kind()).To(Equal(parquet.Int32))
+		})
+
+		It("writes the values of every column", func() {
+			write([]*export.Row{newRow(testConsent), {TCFVersion: 2, CMPID: 300, VendorConsents: []int{1, 2, 3}}})
+			rows := read()
+			Expect(rows).To(HaveLen(2))
+			Expect(rows[0]).To(HaveLen(len(export.Columns())))
+			Expect(rows[0]).To(HaveKeyWithValue("cmp_id", []any{10}))
+			Expect(rows[1]).To(HaveKeyWithValue("cmp_id", []any{300}))
+			Expect(rows[0]).To(HaveKeyWithValue("consent_language", []any{"EN"}))
+			Expect(rows[1]).To(HaveKeyWithValue("consent_language", []any{""}))
+			Expect(rows[0]).To(HaveKeyWithValue("purpose_consent_1", []any{true}))
+			Expect(rows[1]).To(HaveKeyWithValue("purpose_consent_1", []any{false}))
+			created := time.Date(2017, 7, 14, 2, 40, 0, 0, time.UTC).UnixMilli()
+			Expect(rows[0]).To(HaveKeyWithValue("created", []any{created}))
+			Expect(rows[1]).To(HaveKeyWithValue("created", BeEmpty()))
+			Expect(rows[0]).To(HaveKeyWithValue("vendor_consents", []any{5}))
+			Expect(rows[1]).To(HaveKeyWithValue("vendor_consents", []any{1, 2, 3}))
+			Expect(rows[1]).To(HaveKeyWithValue("vendor_legitimate_interests", BeEmpty()))
+		})
+
+		It("writes the publisher restrictions as ranges of vendors", func() {
+			restrictions := []export.Restriction{
+				{PurposeID: 2, RestrictionType: 1, StartVendorID: 7, EndVendorID: 300},
+				{PurposeID: 3, RestrictionType: 0, StartVendorID: 5, EndVendorID: 5},
+			}
+			write([]*export.Row{{TCFVersion: 2, Restrictions: restrictions}})
+			rows := read()
+			Expect(rows[0]).To(HaveKeyWithValue("restriction_purpose_ids", []any{2, 3}))
+			Expect(rows[0]).To(HaveKeyWithValue("restriction_types", []any{1, 0}))
+			Expect(rows[0]).To(HaveKeyWithValue("restriction_start_vendor_ids", []any{7, 5}))
+			Expect(rows[0]).To(HaveKeyWithValue("restriction_end_vendor_ids", []any{300, 5}))
+		})
+
+		It("writes a row group every RowGroupSize rows", func() {
+			write([]*export.Row{newRow(testConsent), newRow(testConsent), newRow(testConsent)}, export.WithRowGroupSize(2))
+			Expect(file.NumRows()).To(BeEquivalentTo(3))
+			groups := file.RowGroups()
+			Expect(groups).To(HaveLen(2))
+			Expect(groups[0].NumRows()).To(BeEquivalentTo(2))
+			Expect(groups[1].NumRows()).To(BeEquivalentTo(1))
+			rows := read()
+			Expect(rows[2]).To(HaveKeyWithValue("cmp_id", []any{10}))
+		})
+
+		It("writes empty files", func() {
+			write(nil)
+			Expect(file.NumRows()).To(BeZero())
+			Expect(read()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,116 @@
+package export
+
+import (
+	"io"
+	"slices"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// DefaultRowGroupSize is the number of rows buffered by default before writing them.
+const DefaultRowGroupSize = 10000
+
+// schemaName is the name of the root of the schema of the files.
+const schemaName = "consent"
+
+// ParquetOption is the type that allows us to configure the ParquetWriter dynamically.
+type ParquetOption func(w *ParquetWriter)
+
+// ParquetWriter writes the rows as a Parquet file, with a row group every RowGroupSize
+// rows. The timestamps are optional INT64 columns in milliseconds, and the lists of IDs
+// repeated INT32 columns. The pages are written uncompressed.
+type ParquetWriter struct {
+	writer       *parquet.Writer
+	builder      *parquet.RowBuilder
+	rows         []parquet.Row
+	rowGroupSize int
+}
+
+// WithRowGroupSize sets the number of rows of every row group, DefaultRowGroupSize by
+// default. Larger row groups compress and scan better but need more memory to write.
+func WithRowGroupSize(rows int) ParquetOption {
+	return func(w *ParquetWriter) {
+		if rows > 0 {
+			w.rowGroupSize = rows
+		}
+	}
+}
+
+// NewParquetWriter returns a writer of the rows as a Parquet file to w.
+func NewParquetWriter(w io.Writer, options ...ParquetOption) *ParquetWriter {
+	writer := &ParquetWriter{rowGroupSize: DefaultRowGroupSize, rows: make([]parquet.Row, 1)}
+	for _, option := range options {
+		option(writer)
+	}
+	schema := parquet.NewSchema(schemaName, newSchemaGroup())
+	writer.writer = parquet.NewWriter(w, schema, parquet.MaxRowsPerRowGroup(int64(writer.rowGroupSize)))
+	writer.builder = parquet.NewRowBuilder(schema)
+	return writer
+}
+
+// Write buffers the row, writing the row group when it's complete.
+func (w *ParquetWriter) Write(row *Row) error {
+	w.builder.Reset()
+	for i, c := range columns {
+		switch v := c.value(row).(type) {
+		case bool:
+			w.builder.Add(i, parquet.BooleanValue(v))
+		case int:
+			w.builder.Add(i, parquet.Int32Value(int32(v)))
+		case string:
+			w.builder.Add(i, parquet.ByteArrayValue([]byte(v)))
+		case time.Time:
+			if !v.IsZero() {
+				w.builder.Add(i, parquet.Int64Value(v.UnixMilli()))
+			}
+		case []int:
+			for _, id := range v {
+				w.builder.Add(i, parquet.Int32Value(int32(id)))
+			}
+		}
+	}
+	w.rows[0] = w.builder.AppendRow(w.rows[0][:0])
+	_, err := w.writer.WriteRows(w.rows)
+	return err
+}
+
+// Close writes the rows buffered and the metadata of the file. It doesn't close the
+// underlying writer.
+func (w *ParquetWriter) Close() error {
+	return w.writer.Close()
+}
+
+// schemaGroup is the group of the columns of the rows. Unlike parquet.Group, which sorts
+// its fields by name, it keeps the columns in the order they are written.
+type schemaGroup struct {
+	parquet.Group
+}
+
+// newSchemaGroup returns the group with a node per column, by the kind of its values.
+func newSchemaGroup() schemaGroup {
+	group := parquet.Group{}
+	for _, c := range columns {
+		switch c.kind {
+		case boolColumn:
+			group[c.name] = parquet.Leaf(parquet.BooleanType)
+		case stringColumn:
+			group[c.name] = parquet.String()
+		case timeColumn:
+			group[c.name] = parquet.Optional(parquet.Timestamp(parquet.Millisecond))
+		case idsColumn:
+			group[c.name] = parquet.Repeated(parquet.Int(32))
+		default:
+			group[c.name] = parquet.Int(32)
+		}
+	}
+	return schemaGroup{group}
+}
+
+// Fields returns the fields of the group, in the order of the columns.
+func (g schemaGroup) Fields() []parquet.Field {
+	names := Columns()
+	return slices.SortedFunc(slices.Values(g.Group.Fields()), func(a, b parquet.Field) int {
+		return slices.Index(names, a.Name()) - slices.Index(names, b.Name())
+	})
+}
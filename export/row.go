@@ -0,0 +1,222 @@
+// Package export converts consents to flat rows, with a fixed column per purpose and
+// arrays of vendor IDs, and writes them as CSV or Parquet so analytics queries don't
+// need to decode the consent strings.
+package export
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+)
+
+const (
+	// NumPurposes is the number of purposes with their own columns, the ones defined by TCF 2.2.
+	NumPurposes = 11
+	// NumSpecialFeatures is the number of special features with their own columns.
+	NumSpecialFeatures = 2
+)
+
+// Writer writes rows in a columnar format. Close must be called to write the rows buffered.
+type Writer interface {
+	Write(row *Row) error
+	Close() error
+}
+
+// Restriction is a publisher restriction of a purpose for a range of vendors, from
+// StartVendorID to EndVendorID, both included.
+type Restriction struct {
+	PurposeID       int
+	RestrictionType int
+	StartVendorID   int
+	EndVendorID     int
+}
+
+// Row is the flat representation of a consent. The fields not present in the consent
+// version are left empty, e.g. the legitimate interests of TCF 1.0 consents.
+type Row struct {
+	TCFVersion                 int
+	Created                    time.Time
+	LastUpdated                time.Time
+	CMPID                      int
+	CMPVersion                 int
+	ConsentScreen              int
+	ConsentLanguage            string
+	VendorListVersion          int
+	TCFPolicyVersion           int
+	IsServiceSpecific          bool
+	PurposeOneTreatment        bool
+	PublisherCC                string
+	PurposeConsents            [NumPurposes]bool
+	PurposeLegitimateInterests [NumPurposes]bool
+	SpecialFeatureOptIns       [NumSpecialFeatures]bool
+	// VendorConsents and VendorLegitimateInterests are the sorted IDs of the vendors allowed.
+	VendorConsents            []int
+	VendorLegitimateInterests []int
+	// Restrictions contains an entry per range of vendors of every publisher restriction.
+	Restrictions []Restriction
+}
+
+// header is implemented by the consents with the metadata of TCF 1.0 and 2.0 strings.
+type header interface {
+	Created() time.Time
+	LastUpdated() time.Time
+	CMPVersion() int
+	ConsentScreen() int
+	ConsentLanguage() string
+	VendorListVersion() int
+}
+
+// vendorIterator is implemented by the consents iterating over their vendors directly.
+type vendorIterator interface {
+	ConsentedVendors() iter.Seq[int]
+	LegitimateInterestVendors() iter.Seq[int]
+}
+
+// NewRow returns the row of the consent. The vendors are read from the bitstrings of
+// consents other than the TCF 1.0 and 2.0 ones.
+func NewRow(consent iab_tcf.Consent) *Row {
+	row := &Row{TCFVersion: consent.Version(), CMPID: consent.CMPID()}
+	if h, ok := consent.(header); ok {
+		row.Created = h.Created()
+		row.LastUpdated = h.LastUpdated()
+		row.CMPVersion = h.CMPVersion()
+		row.ConsentScreen = h.ConsentScreen()
+		row.ConsentLanguage = h.ConsentLanguage()
+		row.VendorListVersion = h.VendorListVersion()
+	}
+	if v2, ok := consent.(*iab_tcf.ConsentV2); ok {
+		row.TCFPolicyVersion = v2.TCFPolicyVersion()
+		row.IsServiceSpecific = v2.IsServiceSpecific()
+		row.PurposeOneTreatment = v2.PurposeOneTreatment()
+		row.PublisherCC = v2.PublisherCC()
+	}
+	for i := range NumPurposes {
+		row.PurposeConsents[i] = consent.HasConsentedPurpose(i + 1)
+		// TCF 1.0 consents have no legitimate interests, their methods always return true.
+		if consent.Version() != 1 {
+			row.PurposeLegitimateInterests[i] = consent.HasConsentedLegitimateInterestForPurpose(i + 1)
+		}
+	}
+	if features, ok := consent.(iab_tcf.SpecialFeatureConsent); ok {
+		for i := range NumSpecialFeatures {
+			row.SpecialFeatureOptIns[i] = features.HasSpecialFeatureOptIn(i + 1)
+		}
+	}
+	if vendors, ok := consent.(vendorIterator); ok {
+		row.VendorConsents = slices.Collect(vendors.ConsentedVendors())
+		row.VendorLegitimateInterests = slices.Collect(vendors.LegitimateInterestVendors())
+	} else {
+		row.VendorConsents = idsFromBits(iab_tcf.AppendConsentBits(nil, consent))
+		row.VendorLegitimateInterests = idsFromBits(iab_tcf.AppendInterestsBits(nil, consent))
+	}
+	for _, restriction := range consent.GetPublisherRestrictions() {
+		for _, entry := range restriction.RestrictionsRange {
+			row.Restrictions = append(row.Restrictions, Restriction{
+				PurposeID:       restriction.PurposeID,
+				RestrictionType: int(restriction.RestrictionType),
+				StartVendorID:   entry.StartVendorID,
+				EndVendorID:     entry.EndVendorID,
+			})
+		}
+	}
+	return row
+}
+
+// idsFromBits returns the IDs set in a bitstring of 1 & 0.
+func idsFromBits(bits []byte) []int {
+	var ids []int
+	for i, bit := range bits {
+		if bit == '1' {
+			ids = append(ids, i+1)
+		}
+	}
+	return ids
+}
+
+// Columns returns the names of the columns of the rows, in the order they are written.
+func Columns() []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// columnKind is the type of the values of a column.
+type columnKind int
+
+const (
+	boolColumn columnKind = iota
+	intColumn
+	stringColumn
+	// timeColumn values are empty for the zero time.
+	timeColumn
+	// idsColumn values are lists of IDs.
+	idsColumn
+)
+
+// column is a column of the rows, with the function returning its value for a row.
+type column struct {
+	name  string
+	kind  columnKind
+	value func(row *Row) any
+}
+
+// columns are the columns of the rows, in the order they are written.
+var columns = newColumns()
+
+// newColumns returns the columns of the rows, with a column per purpose and special feature.
+func newColumns() []column {
+	cols := []column{
+		{"tcf_version", intColumn, func(r *Row) any { return r.TCFVersion }},
+		{"created", timeColumn, func(r *Row) any { return r.Created }},
+		{"last_updated", timeColumn, func(r *Row) any { return r.LastUpdated }},
+		{"cmp_id", intColumn, func(r *Row) any { return r.CMPID }},
+		{"cmp_version", intColumn, func(r *Row) any { return r.CMPVersion }},
+		{"consent_screen", intColumn, func(r *Row) any { return r.ConsentScreen }},
+		{"consent_language", stringColumn, func(r *Row) any { return r.ConsentLanguage }},
+		{"vendor_list_version", intColumn, func(r *Row) any { return r.VendorListVersion }},
+		{"tcf_policy_version", intColumn, func(r *Row) any { return r.TCFPolicyVersion }},
+		{"is_service_specific", boolColumn, func(r *Row) any { return r.IsServiceSpecific }},
+		{"purpose_one_treatment", boolColumn, func(r *Row) any { return r.PurposeOneTreatment }},
+		{"publisher_cc", stringColumn, func(r *Row) any { return r.PublisherCC }},
+	}
+	for i := range NumPurposes {
+		cols = append(cols, column{fmt.Sprintf("purpose_consent_%d", i+1), boolColumn,
+			func(r *Row) any { return r.PurposeConsents[i] }})
+	}
+	for i := range NumPurposes {
+		cols = append(cols, column{fmt.Sprintf("purpose_legitimate_interest_%d", i+1), boolColumn,
+			func(r *Row) any { return r.PurposeLegitimateInterests[i] }})
+	}
+	for i := range NumSpecialFeatures {
+		cols = append(cols, column{fmt.Sprintf("special_feature_opt_in_%d", i+1), boolColumn,
+			func(r *Row) any { return r.SpecialFeatureOptIns[i] }})
+	}
+	return append(cols,
+		column{"vendor_consents", idsColumn, func(r *Row) any { return r.VendorConsents }},
+		column{"vendor_legitimate_interests", idsColumn, func(r *Row) any { return r.VendorLegitimateInterests }},
+		column{"restriction_purpose_ids", idsColumn, func(r *Row) any { return restrictionIDs(r, purposeID) }},
+		column{"restriction_types", idsColumn, func(r *Row) any { return restrictionIDs(r, restrictionType) }},
+		column{"restriction_start_vendor_ids", idsColumn, func(r *Row) any { return restrictionIDs(r, startVendorID) }},
+		column{"restriction_end_vendor_ids", idsColumn, func(r *Row) any { return restrictionIDs(r, endVendorID) }},
+	)
+}
+
+// Fields of the restrictions, written as parallel arrays.
+func purposeID(r Restriction) int       { return r.PurposeID }
+func restrictionType(r Restriction) int { return r.RestrictionType }
+func startVendorID(r Restriction) int   { return r.StartVendorID }
+func endVendorID(r Restriction) int     { return r.EndVendorID }
+
+// restrictionIDs returns the values of a field of every restriction of the row.
+func restrictionIDs(row *Row, field func(Restriction) int) []int {
+	ids := make([]int, len(row.Restrictions))
+	for i, restriction := range row.Restrictions {
+		ids[i] = field(restriction)
+	}
+	return ids
+}
@@ -0,0 +1,200 @@
+package gpp
+
+import (
+	"errors"
+	"maps"
+	"strings"
+
+	"github.com/LiveRamp/iabconsent"
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/usprivacy"
+)
+
+// Section IDs as registered in the GPP specification.
+const (
+	TCFEUv2SID = 2
+	TCFCAv1SID = 5
+	USPv1SID   = 6
+)
+
+var (
+	// ErrSectionsMismatch is returned when the number of sections in the header
+	// doesn't match the number of sections in the string.
+	ErrSectionsMismatch = errors.New("Mismatch between GPP header and sections")
+	// ErrSectionNotFound is returned when trying to decode a section not present in the string.
+	ErrSectionNotFound = errors.New("GPP section not found")
+	// ErrUnsupportedSection is returned when there's no decoder for a section ID.
+	ErrUnsupportedSection = errors.New("Unsupported GPP section")
+)
+
+// Decoder is the type of the functions able to decode the raw value of a section.
+type Decoder func(section string) (interface{}, error)
+
+// Option is the type that allows us to configure the Consent dynamically.
+type Option func(consent *Consent)
+
+// defaultDecoders contains the decoder used by default for each one of the supported
+// section IDs. It's never modified, so it's safe to share between every Consent.
+var defaultDecoders = map[int]Decoder{
+	TCFEUv2SID:       newDecoder(NewTCFEUv2),
+	TCFCAv1SID:       newDecoder(NewTCFCanada),
+	USPv1SID:         newDecoder(usprivacy.NewConsent),
+	USNationalSID:    usSectionDecoder(USNationalSID),
+	USCaliforniaSID:  usSectionDecoder(USCaliforniaSID),
+	USVirginiaSID:    usSectionDecoder(USVirginiaSID),
+	USColoradoSID:    usSectionDecoder(USColoradoSID),
+	USUtahSID:        usSectionDecoder(USUtahSID),
+	USConnecticutSID: usSectionDecoder(USConnecticutSID),
+}
+
+// newDecoder returns the Decoder of a function returning a concrete type, so errors are
+// returned with a nil interface instead of a typed nil.
+func newDecoder[T any](decode func(section string) (T, error)) Decoder {
+	return func(section string) (interface{}, error) {
+		consent, err := decode(section)
+		if err != nil {
+			return nil, err
+		}
+		return consent, nil
+	}
+}
+
+// usSectionDecoder returns the decoder of any of the US National or US state sections.
+func usSectionDecoder(sectionID int) Decoder {
+	return newDecoder(func(section string) (USSection, error) {
+		return NewUSSection(sectionID, section)
+	})
+}
+
+// WithDecoder sets the decoder used by Decode for a section ID, adding support for a
+// section or replacing the default decoder of a supported one.
+func WithDecoder(sectionID int, decoder Decoder) Option {
+	return func(consent *Consent) {
+		consent.decoders[sectionID] = decoder
+	}
+}
+
+// Consent is a GPP string split into its header and its sections, which are
+// only decoded on demand.
+type Consent struct {
+	Header   *iabconsent.GppHeader
+	Sections map[int]string
+	decoders map[int]Decoder
+}
+
+// NewConsent decodes the header of a GPP string (e.g. `DBABM~...`) and splits
+// its sections. It returns an error if the header is invalid or doesn't match
+// the sections found. The options are applied to the decoders of this string only.
+func NewConsent(consent string, options ...Option) (*Consent, error) {
+	segments := strings.Split(consent, "~")
+	header, err := iabconsent.ParseGppHeader(segments[0])
+	if err != nil {
+		if header != nil {
+			// The header is only returned with the error of the reader.
+			err = unexpectedEnd(err)
+		}
+		return nil, err
+	}
+	if len(header.Sections) != len(segments)-1 {
+		return nil, ErrSectionsMismatch
+	}
+	sections := make(map[int]string, len(header.Sections))
+	for i, sectionID := range header.Sections {
+		sections[sectionID] = segments[i+1]
+	}
+	c := &Consent{
+		Header:   header,
+		Sections: sections,
+		decoders: defaultDecoders,
+	}
+	if len(options) > 0 {
+		c.decoders = maps.Clone(defaultDecoders)
+		for _, option := range options {
+			option(c)
+		}
+	}
+	return c, nil
+}
+
+// SectionIDs returns the list of section IDs in the same order as in the header.
+func (c *Consent) SectionIDs() []int {
+	return c.Header.Sections
+}
+
+// HasSection returns if the section ID is present in the string.
+func (c *Consent) HasSection(sectionID int) bool {
+	_, ok := c.Sections[sectionID]
+	return ok
+}
+
+// Decode decodes the section using the decoder registered for its ID.
+func (c *Consent) Decode(sectionID int) (interface{}, error) {
+	section, ok := c.Sections[sectionID]
+	if !ok {
+		return nil, ErrSectionNotFound
+	}
+	decoders := c.decoders
+	if decoders == nil {
+		decoders = defaultDecoders
+	}
+	decoder, ok := decoders[sectionID]
+	if !ok {
+		return nil, ErrUnsupportedSection
+	}
+	return decoder(section)
+}
+
+// TCFEUv2 returns the TCF EU v2 section (ID 2) as a regular Consent.
+func (c *Consent) TCFEUv2() (iab_tcf.Consent, error) {
+	section, ok := c.Sections[TCFEUv2SID]
+	if !ok {
+		return nil, ErrSectionNotFound
+	}
+	return NewTCFEUv2(section)
+}
+
+// TCFCanada returns the TCF Canada section (ID 5).
+func (c *Consent) TCFCanada() (*TCFCanada, error) {
+	section, ok := c.Sections[TCFCAv1SID]
+	if !ok {
+		return nil, ErrSectionNotFound
+	}
+	return NewTCFCanada(section)
+}
+
+// USPrivacy returns the US Privacy section (ID 6).
+func (c *Consent) USPrivacy() (*usprivacy.Consent, error) {
+	section, ok := c.Sections[USPv1SID]
+	if !ok {
+		return nil, ErrSectionNotFound
+	}
+	return usprivacy.NewConsent(section)
+}
+
+// USNational returns the US National section (ID 7).
+func (c *Consent) USNational() (*USNational, error) {
+	section, ok := c.Sections[USNationalSID]
+	if !ok {
+		return nil, ErrSectionNotFound
+	}
+	return NewUSNational(section)
+}
+
+// USSection returns any of the US National or US state sections given its ID.
+func (c *Consent) USSection(sectionID int) (USSection, error) {
+	section, ok := c.Sections[sectionID]
+	if !ok {
+		return nil, ErrSectionNotFound
+	}
+	return NewUSSection(sectionID, section)
+}
+
+// NewTCFEUv2 decodes a TCF EU v2 section, which is a regular TC string, using
+// the same parser as consent strings received outside GPP.
+func NewTCFEUv2(section string) (iab_tcf.Consent, error) {
+	consent, err := iab_tcf.DecodeConsentV2(section)
+	if err != nil {
+		return nil, err
+	}
+	return consent, nil
+}
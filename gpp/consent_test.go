@@ -0,0 +1,122 @@
+package gpp_test
+
+import (
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/gpp"
+	"github.com/hybridtheory/iab-tcf/usprivacy"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Consent", func() {
+
+	const (
+		testTCFConsent = "COxR03kOxR1CqBcABCENAgCMAP_AAH_AAAqIF3EXySoGY2thI2YVFxBEIYwfJxyigMgChgQIsSwNQIeFLBoGLiAAHBGYJAQAGBAEEACBAQIkHGBMCQAAgAgBiRCMQEGMCzNIBIBAggEbY0FACCVmHkHSmZCY7064O__QLuIJEFQMAkSBAIACLECIQwAQDiAAAYAlAAABAhIaAAgIWBQEeAAAACAwAAgAAABBAAACAAQAAICIAAABAAAgAiAQAAAAGgIQAACBABACRIAAAEANCAAgiCEAQg4EAo4AAA"
+		testGPPConsent = "DBACNY~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN"
+	)
+
+	var (
+		consent *gpp.Consent
+		err     error
+	)
+
+	Context("with TCF EU v2 and US Privacy sections", func() {
+		BeforeEach(func() {
+			consent, err = gpp.NewConsent(testGPPConsent)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("decodes the header", func() {
+			Expect(consent.Header.Type).To(Equal(3))
+			Expect(consent.Header.Version).To(Equal(1))
+			Expect(consent.SectionIDs()).To(Equal([]int{gpp.TCFEUv2SID, gpp.USPv1SID}))
+		})
+
+		It("splits the sections", func() {
+			Expect(consent.HasSection(gpp.TCFEUv2SID)).To(BeTrue())
+			Expect(consent.HasSection(gpp.USPv1SID)).To(BeTrue())
+			Expect(consent.HasSection(gpp.TCFCAv1SID)).To(BeFalse())
+			Expect(consent.Sections[gpp.USPv1SID]).To(Equal("1YNN"))
+		})
+
+		It("returns the TCF EU v2 section as a consent", func() {
+			tcf, err := consent.TCFEUv2()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tcf.Version()).To(Equal(2))
+			Expect(tcf.CMPID()).To(Equal(31))
+		})
+
+		It("returns the US Privacy section", func() {
+			usp, err := consent.USPrivacy()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usp.String()).To(Equal("1YNN"))
+		})
+
+		It("dispatches sections to their decoders", func() {
+			section, err := consent.Decode(gpp.TCFEUv2SID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(section).To(BeAssignableToTypeOf(&iab_tcf.ConsentV2{}))
+			section, err = consent.Decode(gpp.USPv1SID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(section).To(BeAssignableToTypeOf(&usprivacy.Consent{}))
+		})
+
+		It("fails to decode sections not present", func() {
+			_, err = consent.Decode(gpp.TCFCAv1SID)
+			Expect(err).To(MatchError(gpp.ErrSectionNotFound))
+		})
+	})
+
+	It("reuses the TCF parser for the TCF EU v2 section", func() {
+		consent, err = gpp.NewConsent("DBABM~" + testTCFConsent)
+		Expect(err).NotTo(HaveOccurred())
+		tcf, err := consent.TCFEUv2()
+		Expect(err).NotTo(HaveOccurred())
+		expected, _ := iab_tcf.NewConsent(testTCFConsent)
+		Expect(tcf.CMPID()).To(Equal(92))
+		Expect(tcf.GetConsentBitstring()).To(Equal(expected.GetConsentBitstring()))
+	})
+
+	It("decodes ranges of section IDs", func() {
+		consent, err = gpp.NewConsent("DBABrGA~BVVqAAEABCA~BVoYYZoI~BVoYYYI~BVoYYQg~BVaGGGCA~BVoYYYQg")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.SectionIDs()).To(Equal([]int{7, 8, 9, 10, 11, 12}))
+	})
+
+	It("fails with sections without decoder", func() {
+		consent, err = gpp.NewConsent("DBABG~BOlLbqtOlLbqtAVABADECg-AAAApp7v")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = consent.Decode(3)
+		Expect(err).To(MatchError(gpp.ErrUnsupportedSection))
+	})
+
+	It("decodes sections with the decoders of the options", func() {
+		decoder := func(section string) (interface{}, error) { return "custom " + section, nil }
+		consent, err = gpp.NewConsent("DBABG~BOlLbqtOlLbqtAVABADECg-AAAApp7v", gpp.WithDecoder(3, decoder))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.Decode(3)).To(Equal("custom BOlLbqtOlLbqtAVABADECg-AAAApp7v"))
+		other, err := gpp.NewConsent("DBABG~BOlLbqtOlLbqtAVABADECg-AAAApp7v")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = other.Decode(3)
+		Expect(err).To(MatchError(gpp.ErrUnsupportedSection))
+	})
+
+	DescribeTable("invalid strings",
+		func(value string) {
+			consent, err = gpp.NewConsent(value)
+			Expect(consent).To(BeNil())
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("invalid header", "badheader~BVVqAAEABCA.QA"),
+		Entry("more sections than in the header", "DBABM~a~b"),
+		Entry("less sections than in the header", "DBACNY~a"),
+	)
+
+	It("returns ErrUnexpectedEnd with truncated headers", func() {
+		_, err = gpp.NewConsent("DB")
+		Expect(err).To(MatchError(iab_tcf.ErrUnexpectedEnd))
+		_, err = gpp.NewConsent("badheader")
+		Expect(err).NotTo(MatchError(iab_tcf.ErrUnexpectedEnd))
+	})
+})
@@ -0,0 +1,137 @@
+package gpp
+
+import (
+	"github.com/LiveRamp/iabconsent"
+)
+
+// USSection is an interface to ask the same questions to the US National and the
+// US state sections, no matter their differences in the fields layout.
+type USSection interface {
+	// SectionID returns the GPP section ID.
+	SectionID() int
+	// Version returns the version of the section specification used to encode it.
+	Version() int
+	// IsOptedOutOfSale returns true if the user has opted out of the sale of their personal data.
+	IsOptedOutOfSale() bool
+	// IsOptedOutOfSharing returns true if the user has opted out of the sharing of their personal data.
+	IsOptedOutOfSharing() bool
+	// IsOptedOutOfTargetedAdvertising returns true if the user has opted out of the processing
+	// of their personal data for targeted advertising.
+	IsOptedOutOfTargetedAdvertising() bool
+	// CanProcessSensitiveData returns true if the category of sensitive data can be processed,
+	// either because the user consented to it or because the user didn't opt out, depending on
+	// the model followed by the section.
+	CanProcessSensitiveData(category int) bool
+	// IsKnownChild returns true if the business has actual knowledge that it processes data
+	// of a known child.
+	IsKnownChild() bool
+	// IsCoveredTransaction returns true if the transaction is covered by the IAB Multi-State
+	// Privacy Agreement (MSPA).
+	IsCoveredTransaction() bool
+	// HasGPC returns true if the Global Privacy Control sub-section is present and set.
+	HasGPC() bool
+}
+
+// mspaSection implements the USSection interface on top of the MSPA parsed consent,
+// shared by the US National and the US state sections.
+type mspaSection struct {
+	ParsedConsent *iabconsent.MspaParsedConsent
+	sectionID     int
+}
+
+// newMSPASection decodes any of the sections based on the MSPA.
+func newMSPASection(sectionID int, section string) (mspaSection, error) {
+	parser := iabconsent.NewMspa(sectionID, section)
+	if parser == nil {
+		return mspaSection{}, ErrUnsupportedSection
+	}
+	parsedConsent, err := parser.ParseConsent()
+	if err != nil {
+		if parsedConsent != nil {
+			// The parsed consent is only returned with the error of the reader.
+			err = unexpectedEnd(err)
+		}
+		return mspaSection{}, err
+	}
+	return mspaSection{
+		ParsedConsent: parsedConsent.(*iabconsent.MspaParsedConsent),
+		sectionID:     sectionID,
+	}, nil
+}
+
+// SectionID returns the GPP section ID.
+func (c mspaSection) SectionID() int {
+	return c.sectionID
+}
+
+// Version returns the version of the section specification used to encode it.
+func (c mspaSection) Version() int {
+	return c.ParsedConsent.Version
+}
+
+// IsOptedOutOfSale returns true if the user has opted out of the sale of their personal data.
+func (c mspaSection) IsOptedOutOfSale() bool {
+	return c.ParsedConsent.SaleOptOut == iabconsent.OptedOut
+}
+
+// IsOptedOutOfSharing returns true if the user has opted out of the sharing of their personal data.
+func (c mspaSection) IsOptedOutOfSharing() bool {
+	return c.ParsedConsent.SharingOptOut == iabconsent.OptedOut
+}
+
+// IsOptedOutOfTargetedAdvertising returns true if the user has opted out of the processing
+// of their personal data for targeted advertising.
+func (c mspaSection) IsOptedOutOfTargetedAdvertising() bool {
+	return c.ParsedConsent.TargetedAdvertisingOptOut == iabconsent.OptedOut
+}
+
+// SensitiveDataConsent returns the consent value for a category of sensitive data.
+// The categories are numbered from 1 as in the specification of each section. Sections
+// following the opt-out model always return iabconsent.ConsentNotApplicable.
+func (c mspaSection) SensitiveDataConsent(category int) iabconsent.MspaConsent {
+	return c.ParsedConsent.SensitiveDataProcessingConsents[category-1]
+}
+
+// SensitiveDataOptOut returns the opt-out value for a category of sensitive data.
+// The categories are numbered from 1 as in the specification of each section. Sections
+// following the consent model always return iabconsent.OptOutNotApplicable.
+func (c mspaSection) SensitiveDataOptOut(category int) iabconsent.MspaOptout {
+	return c.ParsedConsent.SensitiveDataProcessingOptOuts[category-1]
+}
+
+// CanProcessSensitiveData returns true if the user consented to the category of sensitive
+// data or, for the sections following the opt-out model, if the user didn't opt out of it.
+func (c mspaSection) CanProcessSensitiveData(category int) bool {
+	if c.ParsedConsent.SensitiveDataProcessingOptOuts != nil {
+		return c.SensitiveDataOptOut(category) != iabconsent.OptedOut
+	}
+	return c.SensitiveDataConsent(category) == iabconsent.Consent
+}
+
+// KnownChildSensitiveDataConsent returns the consent value to process the data of a known
+// child. The categories are numbered from 1 as in the specification of each section.
+func (c mspaSection) KnownChildSensitiveDataConsent(category int) iabconsent.MspaConsent {
+	return c.ParsedConsent.KnownChildSensitiveDataConsents[category-1]
+}
+
+// IsKnownChild returns true if the business has actual knowledge that it processes data
+// of a known child, i.e. any of the known child fields is applicable.
+func (c mspaSection) IsKnownChild() bool {
+	for _, consent := range c.ParsedConsent.KnownChildSensitiveDataConsents {
+		if consent != iabconsent.ConsentNotApplicable {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCoveredTransaction returns true if the transaction is covered by the IAB Multi-State
+// Privacy Agreement (MSPA).
+func (c mspaSection) IsCoveredTransaction() bool {
+	return c.ParsedConsent.MspaCoveredTransaction == iabconsent.MspaYes
+}
+
+// HasGPC returns true if the Global Privacy Control sub-section is present and set.
+func (c mspaSection) HasGPC() bool {
+	return c.ParsedConsent.Gpc
+}
@@ -0,0 +1,522 @@
+package gpp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LiveRamp/iabconsent"
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/cmp"
+)
+
+// TCFCanadaVersion is the version returned by TCFCanada.Version, the GPP section ID of
+// TCF Canada, so the callers switching on the version of a Consent don't take TCF Canada
+// sections for TCF 1.0 ones. The version of the section itself is returned by SectionVersion.
+const TCFCanadaVersion = TCFCAv1SID
+
+// TCFCanadaParsedConsent contains the fields of a TCF Canada (tcfcav1) section.
+type TCFCanadaParsedConsent struct {
+	Version                      int
+	Created                      time.Time
+	LastUpdated                  time.Time
+	CMPID                        int
+	CMPVersion                   int
+	ConsentScreen                int
+	ConsentLanguage              string
+	VendorListVersion            int
+	TCFPolicyVersion             int
+	UseNonStandardStacks         bool
+	SpecialFeatureExpressConsent map[int]bool
+	PurposesExpressConsent       map[int]bool
+	PurposesImpliedConsent       map[int]bool
+	// The vendor sections are either a bit field or range entries, as the TCF 2.0 ones.
+	MaxExpressVendorID           int
+	IsExpressRangeEncoding       bool
+	VendorExpressConsent         map[int]bool
+	VendorExpressConsentRange    []*iabconsent.RangeEntry
+	MaxImpliedVendorID           int
+	IsImpliedRangeEncoding       bool
+	VendorImpliedConsent         map[int]bool
+	VendorImpliedConsentRange    []*iabconsent.RangeEntry
+	NumPubRestrictions           int
+	PubRestrictionEntries        []*iabconsent.PubRestrictionEntry
+	PubPurposesExpressConsent    map[int]bool
+	PubPurposesImpliedConsent    map[int]bool
+	NumCustomPurposes            int
+	CustomPurposesExpressConsent map[int]bool
+	CustomPurposesImpliedConsent map[int]bool
+}
+
+// TCFCanada is an implementation of the Consent interface used to retrieve
+// consent information from a TCF Canada section, where consent is either
+// express or implied and there's no legitimate interest. The vendors are
+// looked up in sets built from ParsedConsent when the section is decoded.
+type TCFCanada struct {
+	cmp.Consent
+	ParsedConsent *TCFCanadaParsedConsent
+
+	expressVendors iab_tcf.VendorSet
+	impliedVendors iab_tcf.VendorSet
+}
+
+var _ iab_tcf.Consent = &TCFCanada{}
+
+// NewTCFCanada decodes a tcfcav1 section, including its optional publisher
+// purposes segment.
+func NewTCFCanada(section string) (*TCFCanada, error) {
+	segments := strings.Split(section, ".")
+	parsedConsent, err := ParseTCFCanada(segments[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) > 1 {
+		if err = ParseTCFCanadaPublisherPurposes(segments[1], parsedConsent); err != nil {
+			return nil, err
+		}
+	}
+	return &TCFCanada{
+		ParsedConsent:  parsedConsent,
+		expressVendors: vendorSet(parsedConsent.VendorExpressConsent, parsedConsent.VendorExpressConsentRange),
+		impliedVendors: vendorSet(parsedConsent.VendorImpliedConsent, parsedConsent.VendorImpliedConsentRange),
+	}, nil
+}
+
+// Version returns TCFCanadaVersion, distinct from the versions of TC strings.
+func (c *TCFCanada) Version() int {
+	return TCFCanadaVersion
+}
+
+// SectionVersion returns the version of the TCF Canada specification used to encode it.
+func (c *TCFCanada) SectionVersion() int {
+	return c.ParsedConsent.Version
+}
+
+// CMPID returns the CMP ID of this consent string.
+func (c *TCFCanada) CMPID() int {
+	return c.ParsedConsent.CMPID
+}
+
+// IsCMPValid validates the consent string CMP ID agains the list of valid ones downloaded from IAB.
+func (c *TCFCanada) IsCMPValid() bool {
+	return slices.Contains(c.ValidCMPs(), c.CMPID())
+}
+
+// HasExpressConsentForPurpose returns true if the user gave express consent to the purpose.
+func (c *TCFCanada) HasExpressConsentForPurpose(purposeID int) bool {
+	return c.ParsedConsent.PurposesExpressConsent[purposeID]
+}
+
+// HasImpliedConsentForPurpose returns true if the user gave implied consent to the purpose.
+func (c *TCFCanada) HasImpliedConsentForPurpose(purposeID int) bool {
+	return c.ParsedConsent.PurposesImpliedConsent[purposeID]
+}
+
+// HasExpressConsentForSpecialFeature returns true if the user gave express consent to the special feature.
+func (c *TCFCanada) HasExpressConsentForSpecialFeature(featureID int) bool {
+	return c.ParsedConsent.SpecialFeatureExpressConsent[featureID]
+}
+
+// HasSpecialFeatureOptIn returns true if the user gave express consent to the special feature.
+func (c *TCFCanada) HasSpecialFeatureOptIn(featureID int) bool {
+	return c.HasExpressConsentForSpecialFeature(featureID)
+}
+
+// HasConsentedPurpose returns true if the user gave either express or implied consent to the purpose.
+func (c *TCFCanada) HasConsentedPurpose(purposeID int) bool {
+	return c.HasExpressConsentForPurpose(purposeID) || c.HasImpliedConsentForPurpose(purposeID)
+}
+
+// GetConsentPurposeBitstring returns a string of 1 & 0 each of them representing the consent,
+// either express or implied, given for a specific purposeID.
+func (c *TCFCanada) GetConsentPurposeBitstring() string {
+	return iab_tcf.FormatBits(24, c.HasConsentedPurpose)
+}
+
+// AppendConsentPurposeBits appends the GetConsentPurposeBitstring characters to dst
+// and returns the extended buffer.
+func (c *TCFCanada) AppendConsentPurposeBits(dst []byte) []byte {
+	return iab_tcf.AppendBits(dst, 24, c.HasConsentedPurpose)
+}
+
+// HasConsentedLegitimateInterestForPurpose returns always false because TCF Canada doesn't
+// have the legitimate interest legal basis.
+func (c *TCFCanada) HasConsentedLegitimateInterestForPurpose(purposeID int) bool {
+	return false
+}
+
+// HasExpressConsent returns true if the user gave express consent to the vendorID passed
+// as parameter.
+func (c *TCFCanada) HasExpressConsent(vendorID int) bool {
+	return c.expressVendors.Contains(vendorID)
+}
+
+// HasImpliedConsent returns true if the user gave implied consent to the vendorID passed
+// as parameter.
+func (c *TCFCanada) HasImpliedConsent(vendorID int) bool {
+	return c.impliedVendors.Contains(vendorID)
+}
+
+// ExpressConsentVendorSet returns the set of vendor IDs the user gave express consent to.
+func (c *TCFCanada) ExpressConsentVendorSet() iab_tcf.VendorSet {
+	return c.expressVendors
+}
+
+// ImpliedConsentVendorSet returns the set of vendor IDs the user gave implied consent to.
+func (c *TCFCanada) ImpliedConsentVendorSet() iab_tcf.VendorSet {
+	return c.impliedVendors
+}
+
+// HasUserConsented returns true if the user gave either express or implied consent to the
+// vendorID passed as parameter.
+func (c *TCFCanada) HasUserConsented(vendorID int) bool {
+	return c.HasExpressConsent(vendorID) || c.HasImpliedConsent(vendorID)
+}
+
+// HasUserLegitimateInterest returns always false because TCF Canada doesn't have the
+// legitimate interest legal basis.
+func (c *TCFCanada) HasUserLegitimateInterest(vendorID int) bool {
+	return false
+}
+
+// MaxVendorID returns the highest of the maximum vendor IDs of the express and implied
+// consent sections.
+func (c *TCFCanada) MaxVendorID() int {
+	return max(c.ParsedConsent.MaxExpressVendorID, c.ParsedConsent.MaxImpliedVendorID)
+}
+
+// GetConsentBitstring returns a string of 1 & 0 each of them representing the consent, either
+// express or implied, given for a specific vendorID (the first number is for the vendorID 1, and so on).
+func (c *TCFCanada) GetConsentBitstring() string {
+	return iab_tcf.FormatBits(c.MaxVendorID(), c.HasUserConsented)
+}
+
+// AppendConsentBits appends the GetConsentBitstring characters to dst and returns
+// the extended buffer.
+func (c *TCFCanada) AppendConsentBits(dst []byte) []byte {
+	return iab_tcf.AppendBits(dst, c.MaxVendorID(), c.HasUserConsented)
+}
+
+// GetInterestsBitstring returns an empty string always because TCF Canada doesn't
+// have the legitimate interest legal basis.
+func (c *TCFCanada) GetInterestsBitstring() string {
+	return ""
+}
+
+// AppendInterestsBits returns dst as is because TCF Canada doesn't have the legitimate
+// interest legal basis.
+func (c *TCFCanada) AppendInterestsBits(dst []byte) []byte {
+	return dst
+}
+
+// GetPublisherRestrictions returns a copy of the list of publisher restrictions of the section.
+func (c *TCFCanada) GetPublisherRestrictions() []*iabconsent.PubRestrictionEntry {
+	restrictions := make([]*iabconsent.PubRestrictionEntry, len(c.ParsedConsent.PubRestrictionEntries))
+	for i, restriction := range c.ParsedConsent.PubRestrictionEntries {
+		copied := *restriction
+		copied.RestrictionsRange = make([]*iabconsent.RangeEntry, len(restriction.RestrictionsRange))
+		for j, entry := range restriction.RestrictionsRange {
+			copiedEntry := *entry
+			copied.RestrictionsRange[j] = &copiedEntry
+		}
+		restrictions[i] = &copied
+	}
+	return restrictions
+}
+
+// ParseTCFCanada decodes the core segment of a TCF Canada section. The vendor sections
+// are OptimizedIntRange fields, a bit field or range entries as in TCF 2.0 strings, and
+// the range entries ending before their start or past the maximum vendor ID of their
+// section return iab_tcf.ErrInvalidRange.
+func ParseTCFCanada(segment string) (*TCFCanadaParsedConsent, error) {
+	decoded, err := decodeSegment(segment)
+	if err != nil {
+		return nil, err
+	}
+	r := iabconsent.NewConsentReader(decoded)
+	var p = &TCFCanadaParsedConsent{}
+	p.Version, _ = r.ReadInt(6)
+	if p.Version != 1 {
+		return nil, errors.New("Invalid TCF Canada version found")
+	}
+	p.Created, _ = r.ReadTime()
+	p.LastUpdated, _ = r.ReadTime()
+	p.CMPID, _ = r.ReadInt(12)
+	p.CMPVersion, _ = r.ReadInt(12)
+	p.ConsentScreen, _ = r.ReadInt(6)
+	p.ConsentLanguage, _ = r.ReadString(2)
+	p.VendorListVersion, _ = r.ReadInt(12)
+	p.TCFPolicyVersion, _ = r.ReadInt(6)
+	p.UseNonStandardStacks, _ = r.ReadBool()
+	p.SpecialFeatureExpressConsent, _ = r.ReadBitField(12)
+	p.PurposesExpressConsent, _ = r.ReadBitField(24)
+	p.PurposesImpliedConsent, _ = r.ReadBitField(24)
+	if r.Err != nil {
+		return nil, unexpectedEnd(r.Err)
+	}
+	express, err := r.ReadVendors(iabconsent.CoreString)
+	if err != nil {
+		return nil, unexpectedEnd(err)
+	}
+	implied, err := r.ReadVendors(iabconsent.CoreString)
+	if err != nil {
+		return nil, unexpectedEnd(err)
+	}
+	p.MaxExpressVendorID, p.IsExpressRangeEncoding = express.MaxVendorID, express.IsRangeEncoding
+	p.VendorExpressConsent, p.VendorExpressConsentRange = express.Vendors, express.VendorEntries
+	p.MaxImpliedVendorID, p.IsImpliedRangeEncoding = implied.MaxVendorID, implied.IsRangeEncoding
+	p.VendorImpliedConsent, p.VendorImpliedConsentRange = implied.Vendors, implied.VendorEntries
+	if p.NumPubRestrictions, err = r.ReadInt(12); err != nil {
+		return nil, unexpectedEnd(err)
+	}
+	if p.PubRestrictionEntries, err = r.ReadPubRestrictionEntries(uint(p.NumPubRestrictions)); err != nil {
+		return nil, unexpectedEnd(err)
+	}
+	if err = validateRanges(p.VendorExpressConsentRange, p.MaxExpressVendorID); err != nil {
+		return nil, err
+	}
+	if err = validateRanges(p.VendorImpliedConsentRange, p.MaxImpliedVendorID); err != nil {
+		return nil, err
+	}
+	for _, restriction := range p.PubRestrictionEntries {
+		if err = validateRanges(restriction.RestrictionsRange, 1<<16-1); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// ParseTCFCanadaPublisherPurposes decodes the publisher purposes segment of a TCF Canada section
+// into the parsed consent received.
+func ParseTCFCanadaPublisherPurposes(segment string, p *TCFCanadaParsedConsent) error {
+	decoded, err := decodeSegment(segment)
+	if err != nil {
+		return err
+	}
+	r := iabconsent.NewConsentReader(decoded)
+	if segmentType, _ := r.ReadSegmentType(); segmentType != iabconsent.PublisherTC {
+		return errors.New("Invalid TCF Canada segment type found")
+	}
+	p.PubPurposesExpressConsent, _ = r.ReadBitField(24)
+	p.PubPurposesImpliedConsent, _ = r.ReadBitField(24)
+	p.NumCustomPurposes, _ = r.ReadInt(6)
+	p.CustomPurposesExpressConsent, _ = r.ReadBitField(uint(p.NumCustomPurposes))
+	p.CustomPurposesImpliedConsent, _ = r.ReadBitField(uint(p.NumCustomPurposes))
+	return unexpectedEnd(r.Err)
+}
+
+// decodeSegment decodes a base64 segment of a GPP section. Segments are not aligned to
+// bytes, so a 6 bits group of zeros is appended if needed, as iabconsent does with the header.
+func decodeSegment(segment string) ([]byte, error) {
+	if len(segment)%4 == 1 {
+		segment += "A"
+	}
+	return base64.RawURLEncoding.DecodeString(segment)
+}
+
+// vendorSet returns the vendors of a bit field or range entries as a set.
+func vendorSet(vendors map[int]bool, entries []*iabconsent.RangeEntry) iab_tcf.VendorSet {
+	vendorIDs := make([]int, 0, len(vendors))
+	for vendorID, value := range vendors {
+		if value {
+			vendorIDs = append(vendorIDs, vendorID)
+		}
+	}
+	return iab_tcf.NewVendorSet(vendorIDs...).Union(iab_tcf.NewVendorSetFromRanges(entries))
+}
+
+// unexpectedEnd wraps the error of an iabconsent reader with iab_tcf.ErrUnexpectedEnd,
+// as its reads only fail when the data ends before the bits requested.
+func unexpectedEnd(err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %v", iab_tcf.ErrUnexpectedEnd, err)
+}
+
+// validateRanges returns iab_tcf.ErrInvalidRange if any of the range entries ends before
+// its start or past the maximum vendor ID.
+func validateRanges(entries []*iabconsent.RangeEntry, maxVendorID int) error {
+	for _, entry := range entries {
+		if entry.StartVendorID < 1 || entry.EndVendorID < entry.StartVendorID || entry.EndVendorID > maxVendorID {
+			return fmt.Errorf("%w: %d-%d with maximum vendor ID %d", iab_tcf.ErrInvalidRange,
+				entry.StartVendorID, entry.EndVendorID, maxVendorID)
+		}
+	}
+	return nil
+}
+
+// jsonTCFCanadaLegalBases contains the IDs allowed for each legal basis of TCF Canada.
+type jsonTCFCanadaLegalBases struct {
+	ExpressConsents []int `json:"expressConsents"`
+	ImpliedConsents []int `json:"impliedConsents"`
+}
+
+// jsonTCFCanadaVendors is a list of vendors, with the way it was encoded.
+type jsonTCFCanadaVendors struct {
+	MaxVendorID     int   `json:"maxVendorId"`
+	IsRangeEncoding bool  `json:"isRangeEncoding"`
+	IDs             []int `json:"ids"`
+}
+
+// jsonTCFCanadaVendorSections contains the vendors allowed for each legal basis.
+type jsonTCFCanadaVendorSections struct {
+	ExpressConsents jsonTCFCanadaVendors `json:"expressConsents"`
+	ImpliedConsents jsonTCFCanadaVendors `json:"impliedConsents"`
+}
+
+// jsonTCFCanadaRange is a range of vendors, from StartVendorID to EndVendorID, both included.
+type jsonTCFCanadaRange struct {
+	StartVendorID int `json:"startVendorId"`
+	EndVendorID   int `json:"endVendorId"`
+}
+
+// jsonTCFCanadaRestriction is a publisher restriction of a purpose for some ranges of vendors.
+type jsonTCFCanadaRestriction struct {
+	PurposeID       int                  `json:"purposeId"`
+	RestrictionType int                  `json:"restrictionType"`
+	VendorRanges    []jsonTCFCanadaRange `json:"vendorRanges"`
+}
+
+// jsonTCFCanadaPublisherTC is the publisher purposes segment.
+type jsonTCFCanadaPublisherTC struct {
+	Purposes          jsonTCFCanadaLegalBases `json:"purposes"`
+	NumCustomPurposes int                     `json:"numCustomPurposes"`
+	CustomPurposes    jsonTCFCanadaLegalBases `json:"customPurposes"`
+}
+
+// jsonTCFCanadaSegments contains the segments following the core one, if present.
+type jsonTCFCanadaSegments struct {
+	PublisherTC *jsonTCFCanadaPublisherTC `json:"publisherTC,omitempty"`
+}
+
+// jsonTCFCanada is the JSON representation of a TCF Canada section.
+type jsonTCFCanada struct {
+	SchemaVersion                 int                         `json:"schemaVersion"`
+	SectionVersion                int                         `json:"sectionVersion"`
+	Created                       time.Time                   `json:"created"`
+	LastUpdated                   time.Time                   `json:"lastUpdated"`
+	CMPID                         int                         `json:"cmpId"`
+	CMPVersion                    int                         `json:"cmpVersion"`
+	ConsentScreen                 int                         `json:"consentScreen"`
+	ConsentLanguage               string                      `json:"consentLanguage"`
+	VendorListVersion             int                         `json:"vendorListVersion"`
+	TCFPolicyVersion              int                         `json:"tcfPolicyVersion"`
+	UseNonStandardStacks          bool                        `json:"useNonStandardStacks"`
+	Purposes                      jsonTCFCanadaLegalBases     `json:"purposes"`
+	SpecialFeatureExpressConsents []int                       `json:"specialFeatureExpressConsents"`
+	Vendors                       jsonTCFCanadaVendorSections `json:"vendors"`
+	PublisherRestrictions         []jsonTCFCanadaRestriction  `json:"publisherRestrictions"`
+	Segments                      jsonTCFCanadaSegments       `json:"segments"`
+}
+
+// MarshalJSON returns the section following the conventions of the iab_tcf.JSONSchemaVersion
+// schema of TC strings, with express and implied consents instead of consents and legitimate
+// interests, and the version of the section instead of the TCF version:
+//
+//	{
+//	  "schemaVersion": 1,
+//	  "sectionVersion": 1,
+//	  "created": "2020-09-13T12:26:40Z",
+//	  "lastUpdated": "2020-09-13T12:26:40Z",
+//	  "cmpId": 50,
+//	  "cmpVersion": 2,
+//	  "consentScreen": 3,
+//	  "consentLanguage": "EN",
+//	  "vendorListVersion": 48,
+//	  "tcfPolicyVersion": 2,
+//	  "useNonStandardStacks": false,
+//	  "purposes": {"expressConsents": [1, 2], "impliedConsents": [3, 4]},
+//	  "specialFeatureExpressConsents": [1],
+//	  "vendors": {
+//	    "expressConsents": {"maxVendorId": 10, "isRangeEncoding": true, "ids": [2, 3, 4, 10]},
+//	    "impliedConsents": {"maxVendorId": 6, "isRangeEncoding": false, "ids": [5]}
+//	  },
+//	  "publisherRestrictions": [{
+//	    "purposeId": 1,
+//	    "restrictionType": 1,
+//	    "vendorRanges": [{"startVendorId": 3, "endVendorId": 4}]
+//	  }],
+//	  "segments": {
+//	    "publisherTC": {
+//	      "purposes": {"expressConsents": [1], "impliedConsents": [2]},
+//	      "numCustomPurposes": 2,
+//	      "customPurposes": {"expressConsents": [1], "impliedConsents": [2]}
+//	    }
+//	  }
+//	}
+func (c *TCFCanada) MarshalJSON() ([]byte, error) {
+	p := c.ParsedConsent
+	section := jsonTCFCanada{
+		SchemaVersion:        iab_tcf.JSONSchemaVersion,
+		SectionVersion:       p.Version,
+		Created:              p.Created,
+		LastUpdated:          p.LastUpdated,
+		CMPID:                p.CMPID,
+		CMPVersion:           p.CMPVersion,
+		ConsentScreen:        p.ConsentScreen,
+		ConsentLanguage:      p.ConsentLanguage,
+		VendorListVersion:    p.VendorListVersion,
+		TCFPolicyVersion:     p.TCFPolicyVersion,
+		UseNonStandardStacks: p.UseNonStandardStacks,
+		Purposes: jsonTCFCanadaLegalBases{
+			ExpressConsents: idsFromMap(p.PurposesExpressConsent),
+			ImpliedConsents: idsFromMap(p.PurposesImpliedConsent),
+		},
+		SpecialFeatureExpressConsents: idsFromMap(p.SpecialFeatureExpressConsent),
+		Vendors: jsonTCFCanadaVendorSections{
+			ExpressConsents: jsonTCFCanadaVendors{
+				MaxVendorID:     p.MaxExpressVendorID,
+				IsRangeEncoding: p.IsExpressRangeEncoding,
+				IDs:             slices.AppendSeq([]int{}, c.expressVendors.All()),
+			},
+			ImpliedConsents: jsonTCFCanadaVendors{
+				MaxVendorID:     p.MaxImpliedVendorID,
+				IsRangeEncoding: p.IsImpliedRangeEncoding,
+				IDs:             slices.AppendSeq([]int{}, c.impliedVendors.All()),
+			},
+		},
+		PublisherRestrictions: []jsonTCFCanadaRestriction{},
+	}
+	for _, restriction := range p.PubRestrictionEntries {
+		ranges := make([]jsonTCFCanadaRange, len(restriction.RestrictionsRange))
+		for i, entry := range restriction.RestrictionsRange {
+			ranges[i] = jsonTCFCanadaRange{StartVendorID: entry.StartVendorID, EndVendorID: entry.EndVendorID}
+		}
+		section.PublisherRestrictions = append(section.PublisherRestrictions, jsonTCFCanadaRestriction{
+			PurposeID:       restriction.PurposeID,
+			RestrictionType: int(restriction.RestrictionType),
+			VendorRanges:    ranges,
+		})
+	}
+	if p.PubPurposesExpressConsent != nil {
+		section.Segments.PublisherTC = &jsonTCFCanadaPublisherTC{
+			Purposes: jsonTCFCanadaLegalBases{
+				ExpressConsents: idsFromMap(p.PubPurposesExpressConsent),
+				ImpliedConsents: idsFromMap(p.PubPurposesImpliedConsent),
+			},
+			NumCustomPurposes: p.NumCustomPurposes,
+			CustomPurposes: jsonTCFCanadaLegalBases{
+				ExpressConsents: idsFromMap(p.CustomPurposesExpressConsent),
+				ImpliedConsents: idsFromMap(p.CustomPurposesImpliedConsent),
+			},
+		}
+	}
+	return json.Marshal(section)
+}
+
+// idsFromMap returns the sorted IDs set to true in the map.
+func idsFromMap(values map[int]bool) []int {
+	ids := []int{}
+	for id, value := range values {
+		if value {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
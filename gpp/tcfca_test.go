@@ -0,0 +1,278 @@
+package gpp_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/LiveRamp/iabconsent"
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/gpp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TCF Canada", func() {
+
+	const (
+		testTCFCanadaConsent = "BO5rKAAO5rKAAAyACDENAwCQAYAAAGAAAAAVACgAEAAgACgAGBAAgoAMAAwAEA.cAAACAAAAUg"
+	)
+
+	var (
+		consent *gpp.TCFCanada
+		err     error
+	)
+
+	BeforeEach(func() {
+		consent, err = gpp.NewTCFCanada(testTCFCanadaConsent)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("is encoded with the fields of the specification", func() {
+		Expect(testTCFCanadaConsent).To(Equal(tcfCanadaCore(testVendorSections) + "." + encodeFields(
+			3, 3, // segment type
+			1<<23, 24, // publisher purposes express consent: 1
+			1<<22, 24, // publisher purposes implied consent: 2
+			2, 6, // number of custom purposes
+			0b10, 2, // custom purposes express consent: 1
+			0b01, 2, // custom purposes implied consent: 2
+		)))
+	})
+
+	It("implements the Consent interface", func() {
+		var _ iab_tcf.Consent = consent
+	})
+
+	It("decodes the header", func() {
+		Expect(consent.Version()).To(Equal(gpp.TCFCanadaVersion))
+		Expect(consent.SectionVersion()).To(Equal(1))
+		Expect(consent.CMPID()).To(Equal(50))
+		Expect(consent.ParsedConsent.CMPVersion).To(Equal(2))
+		Expect(consent.ParsedConsent.ConsentScreen).To(Equal(3))
+		Expect(consent.ParsedConsent.ConsentLanguage).To(Equal("EN"))
+		Expect(consent.ParsedConsent.VendorListVersion).To(Equal(48))
+		Expect(consent.ParsedConsent.TCFPolicyVersion).To(Equal(2))
+		Expect(consent.ParsedConsent.Created).To(Equal(time.Unix(1600000000, 0).UTC()))
+	})
+
+	DescribeTable("purposes consent",
+		func(purposeID int, express bool, implied bool) {
+			Expect(consent.HasExpressConsentForPurpose(purposeID)).To(Equal(express))
+			Expect(consent.HasImpliedConsentForPurpose(purposeID)).To(Equal(implied))
+			Expect(consent.HasConsentedPurpose(purposeID)).To(Equal(express || implied))
+			Expect(consent.HasConsentedLegitimateInterestForPurpose(purposeID)).To(BeFalse())
+		},
+		Entry("the purpose id 1", 1, true, false),
+		Entry("the purpose id 2", 2, true, false),
+		Entry("the purpose id 3", 3, false, true),
+		Entry("the purpose id 4", 4, false, true),
+		Entry("the purpose id 5", 5, false, false),
+	)
+
+	It("returns the purpose consent bitstring", func() {
+		Expect(consent.GetConsentPurposeBitstring()).To(Equal("111100000000000000000000"))
+	})
+
+	It("returns the special features express consent", func() {
+		Expect(consent.HasExpressConsentForSpecialFeature(1)).To(BeTrue())
+		Expect(consent.HasExpressConsentForSpecialFeature(2)).To(BeFalse())
+		Expect(consent.HasSpecialFeatureOptIn(1)).To(BeTrue())
+	})
+
+	DescribeTable("vendors consent",
+		func(vendorID int, express bool, implied bool) {
+			Expect(consent.HasExpressConsent(vendorID)).To(Equal(express))
+			Expect(consent.HasImpliedConsent(vendorID)).To(Equal(implied))
+			Expect(consent.HasUserConsented(vendorID)).To(Equal(express || implied))
+			Expect(consent.HasUserLegitimateInterest(vendorID)).To(BeFalse())
+		},
+		Entry("the vendor id 1", 1, false, false),
+		Entry("the vendor id 2", 2, true, false),
+		Entry("the vendor id 4", 4, true, false),
+		Entry("the vendor id 5", 5, false, true),
+		Entry("the vendor id 10", 10, true, false),
+		Entry("the vendor id 11", 11, false, false),
+	)
+
+	It("returns the consent bitstrings", func() {
+		Expect(consent.GetConsentBitstring()).To(Equal("0111100001"))
+		Expect(string(consent.AppendConsentBits([]byte("bits:")))).To(Equal("bits:0111100001"))
+		Expect(consent.GetInterestsBitstring()).To(BeEmpty())
+		Expect(consent.AppendInterestsBits(nil)).To(BeEmpty())
+	})
+
+	It("decodes the vendor sections as encoded", func() {
+		Expect(consent.MaxVendorID()).To(Equal(10))
+		Expect(consent.ParsedConsent.MaxExpressVendorID).To(Equal(10))
+		Expect(consent.ParsedConsent.IsExpressRangeEncoding).To(BeTrue())
+		Expect(consent.ParsedConsent.VendorExpressConsentRange).To(Equal([]*iabconsent.RangeEntry{
+			{StartVendorID: 2, EndVendorID: 4},
+			{StartVendorID: 10, EndVendorID: 10},
+		}))
+		Expect(consent.ParsedConsent.MaxImpliedVendorID).To(Equal(6))
+		Expect(consent.ParsedConsent.IsImpliedRangeEncoding).To(BeFalse())
+		Expect(consent.ParsedConsent.VendorImpliedConsent).To(Equal(map[int]bool{5: true}))
+		Expect(consent.ExpressConsentVendorSet().IDs()).To(Equal([]int{2, 3, 4, 10}))
+		Expect(consent.ImpliedConsentVendorSet().IDs()).To(Equal([]int{5}))
+	})
+
+	It("returns the publisher restrictions", func() {
+		Expect(consent.GetPublisherRestrictions()).To(Equal([]*iabconsent.PubRestrictionEntry{{
+			PurposeID:         1,
+			RestrictionType:   iabconsent.RequireConsent,
+			NumEntries:        1,
+			RestrictionsRange: []*iabconsent.RangeEntry{{StartVendorID: 3, EndVendorID: 4}},
+		}}))
+	})
+
+	It("returns copies of the publisher restrictions", func() {
+		restrictions := consent.GetPublisherRestrictions()
+		restrictions[0].PurposeID = 2
+		restrictions[0].RestrictionsRange[0].EndVendorID = 10
+		Expect(consent.GetPublisherRestrictions()[0].PurposeID).To(Equal(1))
+		Expect(consent.GetPublisherRestrictions()[0].RestrictionsRange[0].EndVendorID).To(Equal(4))
+	})
+
+	It("has a version distinct from the TC strings ones", func() {
+		Expect(consent.Version()).NotTo(BeElementOf(1, 2))
+	})
+
+	It("marshals the section as JSON", func() {
+		data, err := json.Marshal(consent)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"schemaVersion": 1,
+			"sectionVersion": 1,
+			"created": "2020-09-13T12:26:40Z",
+			"lastUpdated": "2020-09-13T12:26:40Z",
+			"cmpId": 50,
+			"cmpVersion": 2,
+			"consentScreen": 3,
+			"consentLanguage": "EN",
+			"vendorListVersion": 48,
+			"tcfPolicyVersion": 2,
+			"useNonStandardStacks": false,
+			"purposes": {"expressConsents": [1, 2], "impliedConsents": [3, 4]},
+			"specialFeatureExpressConsents": [1],
+			"vendors": {
+				"expressConsents": {"maxVendorId": 10, "isRangeEncoding": true, "ids": [2, 3, 4, 10]},
+				"impliedConsents": {"maxVendorId": 6, "isRangeEncoding": false, "ids": [5]}
+			},
+			"publisherRestrictions": [{
+				"purposeId": 1,
+				"restrictionType": 1,
+				"vendorRanges": [{"startVendorId": 3, "endVendorId": 4}]
+			}],
+			"segments": {
+				"publisherTC": {
+					"purposes": {"expressConsents": [1], "impliedConsents": [2]},
+					"numCustomPurposes": 2,
+					"customPurposes": {"expressConsents": [1], "impliedConsents": [2]}
+				}
+			}
+		}`))
+	})
+
+	It("decodes the publisher purposes segment", func() {
+		Expect(consent.ParsedConsent.PubPurposesExpressConsent[1]).To(BeTrue())
+		Expect(consent.ParsedConsent.PubPurposesImpliedConsent[2]).To(BeTrue())
+		Expect(consent.ParsedConsent.NumCustomPurposes).To(Equal(2))
+		Expect(consent.ParsedConsent.CustomPurposesExpressConsent[1]).To(BeTrue())
+		Expect(consent.ParsedConsent.CustomPurposesImpliedConsent[2]).To(BeTrue())
+	})
+
+	It("is returned from a GPP string", func() {
+		gppConsent, err := gpp.NewConsent("DBABD~" + testTCFCanadaConsent)
+		Expect(err).NotTo(HaveOccurred())
+		consent, err = gppConsent.TCFCanada()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.CMPID()).To(Equal(50))
+		section, err := gppConsent.Decode(gpp.TCFCAv1SID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(section).To(BeAssignableToTypeOf(&gpp.TCFCanada{}))
+	})
+
+	DescribeTable("invalid strings",
+		func(value string) {
+			consent, err = gpp.NewTCFCanada(value)
+			Expect(consent).To(BeNil())
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("invalid base64", "B*"),
+		Entry("wrong version", "CO5rKAAO5rKAAAyACDENAwCQAYAAAGAAAAAVACgAEAAgACgAGBAAgoAMAAwAEA"),
+		Entry("truncated", "BO5rKAAO5rKAAAyACDENAwCQ"),
+		Entry("without publisher restrictions", tcfCanadaCore([]int{0, 16, 0, 1, 0, 16, 0, 1})),
+		Entry("wrong segment type", "BO5rKAAO5rKAAAyACDENAwCQAYAAAGAAAAAVACgAEAAgACgAGBAAgoAMAAwAEA.IAAACAAAAUg"),
+	)
+
+	It("returns ErrUnexpectedEnd with truncated strings", func() {
+		_, err = gpp.NewTCFCanada("BO5rKAAO5rKAAAyACDENAwCQ")
+		Expect(err).To(MatchError(iab_tcf.ErrUnexpectedEnd))
+	})
+
+	DescribeTable("invalid vendor ranges",
+		func(start, end int) {
+			consent, err = gpp.NewTCFCanada(tcfCanadaCore([]int{
+				10, 16, 1, 1, 1, 12, 1, 1, start, 16, end, 16, // vendor express consent
+				0, 16, 0, 1, // vendor implied consent
+				0, 12, // number of publisher restrictions
+			}))
+			Expect(consent).To(BeNil())
+			Expect(err).To(MatchError(iab_tcf.ErrInvalidRange))
+		},
+		Entry("ending before its start", 8, 3),
+		Entry("ending after the max vendor ID", 3, 11),
+	)
+})
+
+// testVendorSections are the vendor sections and publisher restrictions of the test
+// string, as pairs of value and number of bits.
+var testVendorSections = []int{
+	10, 16, 1, 1, 2, 12, // vendor express consent: max ID 10, 2 range entries
+	1, 1, 2, 16, 4, 16, // 2-4
+	0, 1, 10, 16, // 10
+	6, 16, 0, 1, 0b000010, 6, // vendor implied consent: max ID 6, bit field with 5
+	1, 12, // number of publisher restrictions
+	1, 6, 1, 2, 1, 12, // purpose 1, require consent, 1 range entry
+	1, 1, 3, 16, 4, 16, // 3-4
+}
+
+// tcfCanadaCore returns a TCF Canada core segment with the header of the test string,
+// followed by the fields received as pairs of value and number of bits.
+func tcfCanadaCore(fields []int) string {
+	return encodeFields(append([]int{
+		1, 6, // version
+		16000000000, 36, // created, in deciseconds
+		16000000000, 36, // last updated
+		50, 12, // CMP ID
+		2, 12, // CMP version
+		3, 6, // consent screen
+		'E' - 'A', 6, 'N' - 'A', 6, // consent language
+		48, 12, // vendor list version
+		2, 6, // TCF policy version
+		0, 1, // use non standard stacks
+		1 << 11, 12, // special feature express consent: 1
+		0b11 << 22, 24, // purposes express consent: 1 & 2
+		0b11 << 20, 24, // purposes implied consent: 3 & 4
+	}, fields...)...)
+}
+
+// encodeFields returns the fields received as pairs of value and number of bits encoded
+// as a GPP segment, in the order of the specification and padded with zeros.
+func encodeFields(fields ...int) string {
+	var data []byte
+	length := 0
+	for i := 0; i < len(fields); i += 2 {
+		value, bits := fields[i], fields[i+1]
+		for bit := bits - 1; bit >= 0; bit-- {
+			if length%8 == 0 {
+				data = append(data, 0)
+			}
+			if value>>bit&1 == 1 {
+				data[length/8] |= 0x80 >> (length % 8)
+			}
+			length++
+		}
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
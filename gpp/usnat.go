@@ -0,0 +1,23 @@
+package gpp
+
+import (
+	"github.com/LiveRamp/iabconsent"
+)
+
+// USNationalSID is the section ID of the US National (usnat) section.
+const USNationalSID = iabconsent.UsNationalSID
+
+// USNational is the US National Privacy section of a GPP string, used to signal
+// the choices made by users under the US state privacy laws.
+type USNational struct {
+	mspaSection
+}
+
+// NewUSNational decodes a usnat section, including its optional GPC sub-section.
+func NewUSNational(section string) (*USNational, error) {
+	parsedSection, err := newMSPASection(USNationalSID, section)
+	if err != nil {
+		return nil, err
+	}
+	return &USNational{parsedSection}, nil
+}
@@ -0,0 +1,101 @@
+package gpp_test
+
+import (
+	"github.com/LiveRamp/iabconsent"
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/gpp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("USNational", func() {
+
+	var (
+		consent *gpp.USNational
+		err     error
+	)
+
+	Context("with a GPC sub-section", func() {
+		BeforeEach(func() {
+			consent, err = gpp.NewUSNational("BVVqAAEABCA.YA")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("detects the version as 1", func() {
+			Expect(consent.Version()).To(Equal(1))
+		})
+
+		It("returns the opt outs", func() {
+			Expect(consent.IsOptedOutOfSale()).To(BeFalse())
+			Expect(consent.IsOptedOutOfSharing()).To(BeFalse())
+			Expect(consent.IsOptedOutOfTargetedAdvertising()).To(BeFalse())
+		})
+
+		DescribeTable("sensitive data consents",
+			func(category int, expected iabconsent.MspaConsent) {
+				Expect(consent.SensitiveDataConsent(category)).To(Equal(expected))
+			},
+			Entry("the category 1", 1, iabconsent.ConsentNotApplicable),
+			Entry("the category 8", 8, iabconsent.NoConsent),
+			Entry("the category 12", 12, iabconsent.ConsentNotApplicable),
+			Entry("the category 13", 13, iabconsent.ConsentNotApplicable),
+		)
+
+		It("is not a known child", func() {
+			Expect(consent.IsKnownChild()).To(BeFalse())
+			Expect(consent.KnownChildSensitiveDataConsent(1)).To(Equal(iabconsent.ConsentNotApplicable))
+		})
+
+		It("is not a covered transaction", func() {
+			Expect(consent.IsCoveredTransaction()).To(BeFalse())
+		})
+
+		It("has GPC set", func() {
+			Expect(consent.HasGPC()).To(BeTrue())
+		})
+	})
+
+	Context("without sub-section", func() {
+		BeforeEach(func() {
+			consent, err = gpp.NewUSNational("BqqAqqqqqqA")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("consents every category of sensitive data", func() {
+			for category := 1; category <= 12; category++ {
+				Expect(consent.SensitiveDataConsent(category)).To(Equal(iabconsent.Consent))
+			}
+		})
+
+		It("is a known child", func() {
+			Expect(consent.IsKnownChild()).To(BeTrue())
+			Expect(consent.KnownChildSensitiveDataConsent(2)).To(Equal(iabconsent.Consent))
+		})
+
+		It("doesn't have GPC set", func() {
+			Expect(consent.HasGPC()).To(BeFalse())
+		})
+	})
+
+	It("is returned from a GPP string", func() {
+		gppConsent, err := gpp.NewConsent("DBABLA~BVVqAAEABCA.QA")
+		Expect(err).NotTo(HaveOccurred())
+		consent, err = gppConsent.USNational()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.SensitiveDataConsent(8)).To(Equal(iabconsent.NoConsent))
+		section, err := gppConsent.Decode(gpp.USNationalSID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(section).To(BeAssignableToTypeOf(&gpp.USNational{}))
+	})
+
+	It("fails with invalid versions", func() {
+		_, err = gpp.NewUSNational("CVVqAAEABCA")
+		Expect(err).To(HaveOccurred())
+		Expect(err).NotTo(MatchError(iab_tcf.ErrUnexpectedEnd))
+	})
+
+	It("returns ErrUnexpectedEnd with truncated sections", func() {
+		_, err = gpp.NewUSNational("BV")
+		Expect(err).To(MatchError(iab_tcf.ErrUnexpectedEnd))
+	})
+})
@@ -0,0 +1,107 @@
+package gpp
+
+import (
+	"github.com/LiveRamp/iabconsent"
+)
+
+// Section IDs of the US state sections.
+const (
+	USCaliforniaSID  = iabconsent.UsCaliforniaSID
+	USVirginiaSID    = iabconsent.UsVirginiaSID
+	USColoradoSID    = iabconsent.UsColoradoSID
+	USUtahSID        = iabconsent.UsUtahSID
+	USConnecticutSID = iabconsent.UsConnecticutSID
+)
+
+// USCalifornia is the California (usca) section. It follows the opt-out model for
+// sensitive data, so check SensitiveDataOptOut instead of SensitiveDataConsent.
+type USCalifornia struct {
+	mspaSection
+}
+
+// USVirginia is the Virginia (usva) section.
+type USVirginia struct {
+	mspaSection
+}
+
+// USColorado is the Colorado (usco) section.
+type USColorado struct {
+	mspaSection
+}
+
+// USUtah is the Utah (usut) section. It follows the opt-out model for sensitive data,
+// so check SensitiveDataOptOut instead of SensitiveDataConsent.
+type USUtah struct {
+	mspaSection
+}
+
+// USConnecticut is the Connecticut (usct) section.
+type USConnecticut struct {
+	mspaSection
+}
+
+// NewUSCalifornia decodes a usca section, including its optional GPC sub-section.
+func NewUSCalifornia(section string) (*USCalifornia, error) {
+	parsedSection, err := newMSPASection(USCaliforniaSID, section)
+	if err != nil {
+		return nil, err
+	}
+	return &USCalifornia{parsedSection}, nil
+}
+
+// NewUSVirginia decodes a usva section.
+func NewUSVirginia(section string) (*USVirginia, error) {
+	parsedSection, err := newMSPASection(USVirginiaSID, section)
+	if err != nil {
+		return nil, err
+	}
+	return &USVirginia{parsedSection}, nil
+}
+
+// NewUSColorado decodes a usco section, including its optional GPC sub-section.
+func NewUSColorado(section string) (*USColorado, error) {
+	parsedSection, err := newMSPASection(USColoradoSID, section)
+	if err != nil {
+		return nil, err
+	}
+	return &USColorado{parsedSection}, nil
+}
+
+// NewUSUtah decodes a usut section.
+func NewUSUtah(section string) (*USUtah, error) {
+	parsedSection, err := newMSPASection(USUtahSID, section)
+	if err != nil {
+		return nil, err
+	}
+	return &USUtah{parsedSection}, nil
+}
+
+// NewUSConnecticut decodes a usct section, including its optional GPC sub-section.
+func NewUSConnecticut(section string) (*USConnecticut, error) {
+	parsedSection, err := newMSPASection(USConnecticutSID, section)
+	if err != nil {
+		return nil, err
+	}
+	return &USConnecticut{parsedSection}, nil
+}
+
+// NewUSSection decodes any of the US National or US state sections given its ID.
+func NewUSSection(sectionID int, section string) (USSection, error) {
+	parsedSection, err := newMSPASection(sectionID, section)
+	if err != nil {
+		return nil, err
+	}
+	switch sectionID {
+	case USCaliforniaSID:
+		return &USCalifornia{parsedSection}, nil
+	case USVirginiaSID:
+		return &USVirginia{parsedSection}, nil
+	case USColoradoSID:
+		return &USColorado{parsedSection}, nil
+	case USUtahSID:
+		return &USUtah{parsedSection}, nil
+	case USConnecticutSID:
+		return &USConnecticut{parsedSection}, nil
+	}
+	return &USNational{parsedSection}, nil
+}
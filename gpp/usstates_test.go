@@ -0,0 +1,112 @@
+package gpp_test
+
+import (
+	"github.com/LiveRamp/iabconsent"
+	"github.com/hybridtheory/iab-tcf/gpp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("US state sections", func() {
+
+	const (
+		testGPPConsent = "DBABrGA~BVVqAAEABCA~BVoYYZoI~BVoYYYI~BVoYYQg~BVaGGGCA~BVoYYYQg"
+	)
+
+	var (
+		consent *gpp.Consent
+		err     error
+	)
+
+	BeforeEach(func() {
+		consent, err = gpp.NewConsent(testGPPConsent)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	DescribeTable("common interface",
+		func(sectionID int, expected gpp.USSection) {
+			section, err := consent.USSection(sectionID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(section).To(BeAssignableToTypeOf(expected))
+			Expect(section.SectionID()).To(Equal(sectionID))
+			Expect(section.Version()).To(Equal(1))
+			Expect(section.IsOptedOutOfSale()).To(BeFalse())
+			Expect(section.IsCoveredTransaction()).To(BeFalse())
+			Expect(section.HasGPC()).To(BeFalse())
+			decoded, err := consent.Decode(sectionID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decoded).To(BeAssignableToTypeOf(expected))
+		},
+		Entry("US National", gpp.USNationalSID, &gpp.USNational{}),
+		Entry("California", gpp.USCaliforniaSID, &gpp.USCalifornia{}),
+		Entry("Virginia", gpp.USVirginiaSID, &gpp.USVirginia{}),
+		Entry("Colorado", gpp.USColoradoSID, &gpp.USColorado{}),
+		Entry("Utah", gpp.USUtahSID, &gpp.USUtah{}),
+		Entry("Connecticut", gpp.USConnecticutSID, &gpp.USConnecticut{}),
+	)
+
+	Context("California", func() {
+		var section *gpp.USCalifornia
+
+		BeforeEach(func() {
+			section, err = gpp.NewUSCalifornia("BVoYYZoI")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("follows the opt-out model for sensitive data", func() {
+			Expect(section.SensitiveDataOptOut(2)).To(Equal(iabconsent.OptedOut))
+			Expect(section.SensitiveDataConsent(2)).To(Equal(iabconsent.ConsentNotApplicable))
+		})
+
+		DescribeTable("sensitive data processing",
+			func(category int, expected bool) {
+				Expect(section.CanProcessSensitiveData(category)).To(Equal(expected))
+			},
+			Entry("the category 1 not applicable", 1, true),
+			Entry("the category 2 opted out", 2, false),
+			Entry("the category 3 not opted out", 3, true),
+			Entry("the category 8 opted out", 8, false),
+		)
+
+		It("is a known child", func() {
+			Expect(section.IsKnownChild()).To(BeTrue())
+			Expect(section.KnownChildSensitiveDataConsent(1)).To(Equal(iabconsent.NoConsent))
+			Expect(section.KnownChildSensitiveDataConsent(2)).To(Equal(iabconsent.Consent))
+		})
+	})
+
+	Context("Virginia", func() {
+		var section *gpp.USVirginia
+
+		BeforeEach(func() {
+			section, err = gpp.NewUSVirginia("BVoYYYI")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("follows the consent model for sensitive data", func() {
+			for category := 1; category <= 8; category++ {
+				Expect(section.CanProcessSensitiveData(category)).To(
+					Equal(section.SensitiveDataConsent(category) == iabconsent.Consent))
+			}
+			Expect(section.SensitiveDataOptOut(1)).To(Equal(iabconsent.OptOutNotApplicable))
+		})
+	})
+
+	It("decodes the GPC sub-section", func() {
+		section, err := gpp.NewUSConnecticut("BVoYYYQg.YA")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(section.HasGPC()).To(BeTrue())
+	})
+
+	It("fails with unsupported section IDs", func() {
+		_, err = gpp.NewUSSection(gpp.TCFEUv2SID, "BVoYYYQg")
+		Expect(err).To(MatchError(gpp.ErrUnsupportedSection))
+	})
+
+	It("fails with sections not present", func() {
+		consent, err = gpp.NewConsent("DBABLA~BVVqAAEABCA.QA")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = consent.USSection(gpp.USCaliforniaSID)
+		Expect(err).To(MatchError(gpp.ErrSectionNotFound))
+	})
+})
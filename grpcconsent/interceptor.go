@@ -0,0 +1,176 @@
+package grpcconsent
+
+import (
+	"context"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMetadataKey is the metadata key the consent string is read from and forwarded with.
+const DefaultMetadataKey = "gdpr-consent"
+
+// Option is the type that allows us to configure the Interceptor dynamically.
+type Option func(interceptor *Interceptor)
+
+// Interceptor is the type that contains the logic to read the consent string from the
+// incoming gRPC metadata, attach the parsed consent to the context and forward the
+// original string in the outgoing metadata.
+type Interceptor struct {
+	// MetadataKeys are the keys the consent string is read from, in order. The first one
+	// is used to forward it downstream.
+	MetadataKeys  []string
+	RejectInvalid bool
+}
+
+// WithMetadataKeys allows to configure the metadata keys to read the consent string from.
+// The first one is used to forward it downstream.
+func WithMetadataKeys(keys ...string) Option {
+	return func(interceptor *Interceptor) {
+		interceptor.MetadataKeys = keys
+	}
+}
+
+// WithRejectInvalid makes the server interceptors reply with an `InvalidArgument` error to
+// the calls with invalid consent strings, instead of tagging them with the error.
+func WithRejectInvalid() Option {
+	return func(interceptor *Interceptor) {
+		interceptor.RejectInvalid = true
+	}
+}
+
+// NewInterceptor returns an interceptor instance.
+func NewInterceptor(options ...Option) *Interceptor {
+	interceptor := &Interceptor{
+		MetadataKeys: []string{DefaultMetadataKey},
+	}
+	for _, option := range options {
+		option(interceptor)
+	}
+	return interceptor
+}
+
+// UnaryServerInterceptor returns a server interceptor that attaches the consent of every
+// unary call to its context.
+func (interceptor *Interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := interceptor.incomingContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor that attaches the consent of every
+// stream to its context.
+func (interceptor *Interceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := interceptor.incomingContext(stream.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// UnaryClientInterceptor returns a client interceptor that forwards the consent string of
+// the context in the metadata of every unary call.
+func (interceptor *Interceptor) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(interceptor.outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor returns a client interceptor that forwards the consent string of
+// the context in the metadata of every stream.
+func (interceptor *Interceptor) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(interceptor.outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// ConsentString returns the consent string of the incoming metadata, looking at the
+// metadata keys in order.
+func (interceptor *Interceptor) ConsentString(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, key := range interceptor.MetadataKeys {
+		for _, value := range md.Get(key) {
+			if value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// incomingContext returns a copy of the context with the consent string of the incoming
+// metadata and its parsed consent, or the parse error.
+func (interceptor *Interceptor) incomingContext(ctx context.Context) (context.Context, error) {
+	value := interceptor.ConsentString(ctx)
+	if value == "" {
+		return ctx, nil
+	}
+	ctx = NewConsentStringContext(ctx, value)
+	consent, err := iab_tcf.NewConsent(value)
+	if err != nil {
+		if interceptor.RejectInvalid {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return iab_tcf.NewErrorContext(ctx, err), nil
+	}
+	return iab_tcf.NewContext(ctx, consent), nil
+}
+
+// outgoingContext returns a copy of the context with the consent string in the outgoing
+// metadata, unless it's already there.
+func (interceptor *Interceptor) outgoingContext(ctx context.Context) context.Context {
+	value := ConsentStringFromContext(ctx)
+	if value == "" || len(interceptor.MetadataKeys) == 0 {
+		return ctx
+	}
+	key := interceptor.MetadataKeys[0]
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(key)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, key, value)
+}
+
+// serverStream overrides the context of a server stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context with the consent.
+func (stream *serverStream) Context() context.Context {
+	return stream.ctx
+}
+
+// NewConsentStringContext returns a copy of the context carrying the raw consent string
+// received, so the client interceptors forward it downstream.
+func NewConsentStringContext(ctx context.Context, value string) context.Context {
+	return iab_tcf.NewConsentStringContext(ctx, value)
+}
+
+// FromContext returns the consent attached to the call context by the interceptors.
+func FromContext(ctx context.Context) (iab_tcf.Consent, bool) {
+	return iab_tcf.FromContext(ctx)
+}
+
+// ErrorFromContext returns the error found parsing the consent string of the call, if any.
+func ErrorFromContext(ctx context.Context) error {
+	return iab_tcf.ErrorFromContext(ctx)
+}
+
+// ConsentStringFromContext returns the raw consent string of the call, no matter if it
+// was valid or not.
+func ConsentStringFromContext(ctx context.Context) string {
+	return iab_tcf.ConsentStringFromContext(ctx)
+}
@@ -0,0 +1,156 @@
+package grpcconsent_test
+
+import (
+	"context"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/grpcconsent"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *testServerStream) Context() context.Context {
+	return stream.ctx
+}
+
+var _ = Describe("Interceptor", func() {
+
+	const testConsent = "COyt4MbOyt4MbMOAAAENAiCgAIAAAAAAAAAAADEAAgIAAAAAAAA"
+
+	var (
+		interceptor *grpcconsent.Interceptor
+		handled     context.Context
+	)
+
+	incoming := func(pairs ...string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+	}
+
+	unary := func(ctx context.Context) error {
+		handled = nil
+		_, err := interceptor.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			handled = ctx
+			return nil, nil
+		})
+		return err
+	}
+
+	outgoing := func(ctx context.Context) metadata.MD {
+		var md metadata.MD
+		err := interceptor.UnaryClientInterceptor()(ctx, "/test.Service/Method", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return md
+	}
+
+	BeforeEach(func() {
+		interceptor = grpcconsent.NewInterceptor()
+	})
+
+	Context("unary server", func() {
+
+		It("attaches the consent to the context", func() {
+			Expect(unary(incoming(grpcconsent.DefaultMetadataKey, testConsent))).To(Succeed())
+			consent, ok := grpcconsent.FromContext(handled)
+			Expect(ok).To(BeTrue())
+			Expect(consent.CMPID()).To(Equal(782))
+			Expect(grpcconsent.ConsentStringFromContext(handled)).To(Equal(testConsent))
+			Expect(grpcconsent.ErrorFromContext(handled)).NotTo(HaveOccurred())
+		})
+
+		It("reads the consent from the configured metadata keys in order", func() {
+			interceptor = grpcconsent.NewInterceptor(grpcconsent.WithMetadataKeys("x-consent", "x-tc-string"))
+			Expect(unary(incoming("x-tc-string", testConsent))).To(Succeed())
+			Expect(grpcconsent.ConsentStringFromContext(handled)).To(Equal(testConsent))
+			Expect(unary(incoming("x-consent", "first", "x-tc-string", testConsent))).To(Succeed())
+			Expect(grpcconsent.ConsentStringFromContext(handled)).To(Equal("first"))
+		})
+
+		It("leaves the context untouched without consent", func() {
+			Expect(unary(context.Background())).To(Succeed())
+			_, ok := grpcconsent.FromContext(handled)
+			Expect(ok).To(BeFalse())
+			Expect(grpcconsent.ConsentStringFromContext(handled)).To(BeEmpty())
+		})
+
+		It("tags the context with the error of invalid consents", func() {
+			Expect(unary(incoming(grpcconsent.DefaultMetadataKey, "invalid"))).To(Succeed())
+			_, ok := grpcconsent.FromContext(handled)
+			Expect(ok).To(BeFalse())
+			Expect(grpcconsent.ErrorFromContext(handled)).To(HaveOccurred())
+			Expect(grpcconsent.ConsentStringFromContext(handled)).To(Equal("invalid"))
+		})
+
+		It("rejects invalid consents when configured", func() {
+			interceptor = grpcconsent.NewInterceptor(grpcconsent.WithRejectInvalid())
+			err := unary(incoming(grpcconsent.DefaultMetadataKey, "invalid"))
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(handled).To(BeNil())
+		})
+	})
+
+	Context("stream server", func() {
+
+		It("attaches the consent to the stream context", func() {
+			stream := &testServerStream{ctx: incoming(grpcconsent.DefaultMetadataKey, testConsent)}
+			err := interceptor.StreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+				handled = stream.Context()
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			consent, ok := grpcconsent.FromContext(handled)
+			Expect(ok).To(BeTrue())
+			Expect(consent.CMPID()).To(Equal(782))
+		})
+	})
+
+	Context("client", func() {
+
+		It("forwards the consent string downstream", func() {
+			Expect(unary(incoming(grpcconsent.DefaultMetadataKey, testConsent))).To(Succeed())
+			Expect(outgoing(handled).Get(grpcconsent.DefaultMetadataKey)).To(Equal([]string{testConsent}))
+		})
+
+		It("forwards the consent string set explicitly", func() {
+			ctx := grpcconsent.NewConsentStringContext(context.Background(), testConsent)
+			Expect(outgoing(ctx).Get(grpcconsent.DefaultMetadataKey)).To(Equal([]string{testConsent}))
+		})
+
+		It("forwards the consent string of the HTTP requests", func() {
+			ctx := iab_tcf.NewConsentStringContext(context.Background(), testConsent)
+			Expect(outgoing(ctx).Get(grpcconsent.DefaultMetadataKey)).To(Equal([]string{testConsent}))
+		})
+
+		It("keeps the consent string already in the outgoing metadata", func() {
+			ctx := grpcconsent.NewConsentStringContext(context.Background(), testConsent)
+			ctx = metadata.AppendToOutgoingContext(ctx, grpcconsent.DefaultMetadataKey, "other")
+			Expect(outgoing(ctx).Get(grpcconsent.DefaultMetadataKey)).To(Equal([]string{"other"}))
+		})
+
+		It("forwards nothing without consent", func() {
+			Expect(outgoing(context.Background())).To(BeNil())
+		})
+
+		It("forwards the consent string of streams", func() {
+			ctx := grpcconsent.NewConsentStringContext(context.Background(), testConsent)
+			var md metadata.MD
+			_, err := interceptor.StreamClientInterceptor()(ctx, &grpc.StreamDesc{}, nil, "/test.Service/Stream", func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				md, _ = metadata.FromOutgoingContext(ctx)
+				return nil, nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(md.Get(grpcconsent.DefaultMetadataKey)).To(Equal([]string{testConsent}))
+		})
+	})
+})
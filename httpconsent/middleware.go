@@ -0,0 +1,120 @@
+package httpconsent
+
+import (
+	"context"
+	"net/http"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+)
+
+const (
+	// DefaultQueryParam is the query parameter the consent string is read from by default,
+	// the one used by the OpenRTB and ad server integrations.
+	DefaultQueryParam = "gdpr_consent"
+	// DefaultCookieName is the cookie the consent string is read from by default, the
+	// one set by TCF 2.0 CMPs on their own domain.
+	DefaultCookieName = "euconsent-v2"
+)
+
+// Option is the type that allows us to configure the Middleware dynamically.
+type Option func(middleware *Middleware)
+
+// Middleware is the type that contains the logic to extract the consent string
+// from HTTP requests and attach the parsed consent to their context.
+type Middleware struct {
+	QueryParam    string
+	CookieName    string
+	RejectInvalid bool
+	RejectStatus  int
+}
+
+// WithQueryParam allows to configure a different query string parameter to read
+// the consent string from.
+func WithQueryParam(name string) Option {
+	return func(middleware *Middleware) {
+		middleware.QueryParam = name
+	}
+}
+
+// WithCookie allows to configure a different cookie to read the consent string from
+// when it's not present in the query string.
+func WithCookie(name string) Option {
+	return func(middleware *Middleware) {
+		middleware.CookieName = name
+	}
+}
+
+// WithRejectInvalid makes the middleware reply with the status code received to the
+// requests with invalid consent strings, instead of tagging them with the error.
+func WithRejectInvalid(status int) Option {
+	return func(middleware *Middleware) {
+		middleware.RejectInvalid = true
+		middleware.RejectStatus = status
+	}
+}
+
+// NewMiddleware returns a middleware instance.
+func NewMiddleware(options ...Option) *Middleware {
+	middleware := &Middleware{
+		QueryParam:   DefaultQueryParam,
+		CookieName:   DefaultCookieName,
+		RejectStatus: http.StatusBadRequest,
+	}
+	for _, option := range options {
+		option(middleware)
+	}
+	return middleware
+}
+
+// Handler wraps the handler received so every request with a consent string reaches it
+// with the parsed consent, or the parse error, in its context.
+func (middleware *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := middleware.ConsentString(r)
+		if value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := iab_tcf.NewConsentStringContext(r.Context(), value)
+		consent, err := iab_tcf.NewConsent(value)
+		if err != nil {
+			if middleware.RejectInvalid {
+				http.Error(w, http.StatusText(middleware.RejectStatus), middleware.RejectStatus)
+				return
+			}
+			ctx = iab_tcf.NewErrorContext(ctx, err)
+		} else {
+			ctx = iab_tcf.NewContext(ctx, consent)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ConsentString returns the consent string of the request, looking first at the query
+// string and then at the cookie.
+func (middleware *Middleware) ConsentString(r *http.Request) string {
+	if value := r.URL.Query().Get(middleware.QueryParam); value != "" {
+		return value
+	}
+	if cookie, err := r.Cookie(middleware.CookieName); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// FromContext returns the consent attached to the request context by the middleware.
+func FromContext(ctx context.Context) (iab_tcf.Consent, bool) {
+	return iab_tcf.FromContext(ctx)
+}
+
+// ErrorFromContext returns the error found parsing the consent string of the request,
+// if any.
+func ErrorFromContext(ctx context.Context) error {
+	return iab_tcf.ErrorFromContext(ctx)
+}
+
+// ConsentStringFromContext returns the raw consent string of the request, no matter if
+// it was valid or not.
+func ConsentStringFromContext(ctx context.Context) string {
+	return iab_tcf.ConsentStringFromContext(ctx)
+}
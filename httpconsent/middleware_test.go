@@ -0,0 +1,105 @@
+package httpconsent_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/httpconsent"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Middleware", func() {
+
+	const (
+		testConsent = "COyt4MbOyt4MbMOAAAENAiCgAIAAAAAAAAAAADEAAgIAAAAAAAA"
+	)
+
+	var (
+		recorder *httptest.ResponseRecorder
+		request  *http.Request
+		consent  iab_tcf.Consent
+		found    bool
+		err      error
+		raw      string
+		called   bool
+		handler  = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			consent, found = httpconsent.FromContext(r.Context())
+			err = httpconsent.ErrorFromContext(r.Context())
+			raw = httpconsent.ConsentStringFromContext(r.Context())
+		})
+	)
+
+	BeforeEach(func() {
+		recorder = httptest.NewRecorder()
+		called, consent, found, err, raw = false, nil, false, nil, ""
+	})
+
+	serve := func(middleware *httpconsent.Middleware) {
+		middleware.Handler(handler).ServeHTTP(recorder, request)
+	}
+
+	It("reads the consent from the query string", func() {
+		request = httptest.NewRequest(http.MethodGet, "/?gdpr_consent="+testConsent, nil)
+		serve(httpconsent.NewMiddleware())
+		Expect(found).To(BeTrue())
+		Expect(consent.CMPID()).To(Equal(782))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(raw).To(Equal(testConsent))
+	})
+
+	It("reads the consent from the cookie", func() {
+		request = httptest.NewRequest(http.MethodGet, "/", nil)
+		request.AddCookie(&http.Cookie{Name: "euconsent-v2", Value: testConsent})
+		serve(httpconsent.NewMiddleware())
+		Expect(found).To(BeTrue())
+		Expect(consent.CMPID()).To(Equal(782))
+	})
+
+	It("prefers the query string over the cookie", func() {
+		request = httptest.NewRequest(http.MethodGet, "/?gdpr_consent=invalid", nil)
+		request.AddCookie(&http.Cookie{Name: "euconsent-v2", Value: testConsent})
+		serve(httpconsent.NewMiddleware())
+		Expect(found).To(BeFalse())
+		Expect(raw).To(Equal("invalid"))
+	})
+
+	It("can be configured", func() {
+		request = httptest.NewRequest(http.MethodGet, "/?consent="+testConsent, nil)
+		serve(httpconsent.NewMiddleware(httpconsent.WithQueryParam("consent"), httpconsent.WithCookie("tcf")))
+		Expect(found).To(BeTrue())
+		request = httptest.NewRequest(http.MethodGet, "/", nil)
+		request.AddCookie(&http.Cookie{Name: "tcf", Value: testConsent})
+		serve(httpconsent.NewMiddleware(httpconsent.WithCookie("tcf")))
+		Expect(found).To(BeTrue())
+	})
+
+	It("passes through requests without consent", func() {
+		request = httptest.NewRequest(http.MethodGet, "/", nil)
+		serve(httpconsent.NewMiddleware(httpconsent.WithRejectInvalid(http.StatusForbidden)))
+		Expect(called).To(BeTrue())
+		Expect(found).To(BeFalse())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("with invalid consent strings", func() {
+		BeforeEach(func() {
+			request = httptest.NewRequest(http.MethodGet, "/?gdpr_consent=invalid", nil)
+		})
+
+		It("tags the request with the error", func() {
+			serve(httpconsent.NewMiddleware())
+			Expect(called).To(BeTrue())
+			Expect(found).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects the request if configured", func() {
+			serve(httpconsent.NewMiddleware(httpconsent.WithRejectInvalid(http.StatusForbidden)))
+			Expect(called).To(BeFalse())
+			Expect(recorder.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
@@ -0,0 +1,524 @@
+package iab_tcf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LiveRamp/iabconsent"
+)
+
+// JSONSchemaVersion is the version of the JSON representation of the consents, increased
+// on every incompatible change of the schema.
+//
+// The schema of a consent, with the fields only present for TCF 2.0 consents marked, is:
+//
+//	{
+//	  "schemaVersion": 1,
+//	  "tcfVersion": 2,
+//	  "created": "2020-05-01T09:23:22.1Z",
+//	  "lastUpdated": "2020-05-01T09:23:22.1Z",
+//	  "cmpId": 10,
+//	  "cmpVersion": 1,
+//	  "consentScreen": 1,
+//	  "consentLanguage": "EN",
+//	  "vendorListVersion": 34,
+//	  "tcfPolicyVersion": 2,                   // TCF 2.0 only
+//	  "isServiceSpecific": false,              // TCF 2.0 only
+//	  "useNonStandardStacks": false,           // TCF 2.0 only
+//	  "purposeOneTreatment": false,            // TCF 2.0 only
+//	  "publisherCC": "AA",                     // TCF 2.0 only
+//	  "purposes": {"consents": [1, 2], "legitimateInterests": [3]},
+//	  "specialFeatureOptIns": [1],             // TCF 2.0 only
+//	  "vendors": {
+//	    "consents": {"maxVendorId": 10, "isRangeEncoding": false, "ids": [5]},
+//	    "legitimateInterests": {"maxVendorId": 0, "isRangeEncoding": false, "ids": []}
+//	  },
+//	  "publisherRestrictions": [{
+//	    "purposeId": 1,
+//	    "restrictionType": 0,
+//	    "vendorRanges": [{"startVendorId": 5, "endVendorId": 5}]
+//	  }],
+//	  "segments": {
+//	    "disclosedVendors": {"maxVendorId": 10, "isRangeEncoding": false, "ids": [5]},
+//	    "allowedVendors": {"maxVendorId": 10, "isRangeEncoding": false, "ids": [5]},
+//	    "publisherTC": {
+//	      "purposes": {"consents": [1], "legitimateInterests": [2]},
+//	      "numCustomPurposes": 2,
+//	      "customPurposes": {"consents": [1], "legitimateInterests": [2]}
+//	    }
+//	  }
+//	}
+//
+// The IDs are always sorted, while the vendor ranges of the publisher restrictions are kept
+// as encoded. The legitimate interests are omitted for TCF 1.0 consents, as well as the
+// publisher restrictions and segments, and the segments not present.
+const JSONSchemaVersion = 1
+
+// Limits of the fields of the publisher restrictions, given by the bits reserved for them.
+const (
+	maxRestrictionPurposeID = 1<<6 - 1
+	maxRestrictionType      = 1<<2 - 1
+)
+
+// ErrUnsupportedSchema is returned when unmarshalling a JSON schema version not supported.
+var ErrUnsupportedSchema = errors.New("Unsupported JSON schema version")
+
+// jsonVersion contains the fields needed to know how to unmarshal a consent.
+type jsonVersion struct {
+	SchemaVersion int `json:"schemaVersion"`
+	TCFVersion    int `json:"tcfVersion"`
+}
+
+// jsonHeader contains the metadata shared by every TCF version.
+type jsonHeader struct {
+	jsonVersion
+	Created           time.Time `json:"created"`
+	LastUpdated       time.Time `json:"lastUpdated"`
+	CMPID             int       `json:"cmpId"`
+	CMPVersion        int       `json:"cmpVersion"`
+	ConsentScreen     int       `json:"consentScreen"`
+	ConsentLanguage   string    `json:"consentLanguage"`
+	VendorListVersion int       `json:"vendorListVersion"`
+}
+
+// jsonConsents contains the IDs allowed on the legal basis of consent.
+type jsonConsents struct {
+	Consents []int `json:"consents"`
+}
+
+// jsonLegalBases contains the IDs allowed for each legal basis.
+type jsonLegalBases struct {
+	Consents            []int `json:"consents"`
+	LegitimateInterests []int `json:"legitimateInterests"`
+}
+
+// jsonVendors is a list of vendors, with the way it was encoded.
+type jsonVendors struct {
+	MaxVendorID     int   `json:"maxVendorId"`
+	IsRangeEncoding bool  `json:"isRangeEncoding"`
+	IDs             []int `json:"ids"`
+}
+
+// jsonVendorConsents contains the vendors allowed on the legal basis of consent.
+type jsonVendorConsents struct {
+	Consents jsonVendors `json:"consents"`
+}
+
+// jsonVendorSections contains the vendors allowed for each legal basis.
+type jsonVendorSections struct {
+	Consents            jsonVendors `json:"consents"`
+	LegitimateInterests jsonVendors `json:"legitimateInterests"`
+}
+
+// jsonRange is a range of vendors, from StartVendorID to EndVendorID, both included.
+type jsonRange struct {
+	StartVendorID int `json:"startVendorId"`
+	EndVendorID   int `json:"endVendorId"`
+}
+
+// jsonRestriction is a publisher restriction of a purpose for some ranges of vendors.
+type jsonRestriction struct {
+	PurposeID       int         `json:"purposeId"`
+	RestrictionType int         `json:"restrictionType"`
+	VendorRanges    []jsonRange `json:"vendorRanges"`
+}
+
+// jsonPublisherTC is the publisher purposes segment.
+type jsonPublisherTC struct {
+	Purposes          jsonLegalBases `json:"purposes"`
+	NumCustomPurposes int            `json:"numCustomPurposes"`
+	CustomPurposes    jsonLegalBases `json:"customPurposes"`
+}
+
+// jsonSegments contains the segments following the core one, if present.
+type jsonSegments struct {
+	DisclosedVendors *jsonVendors     `json:"disclosedVendors,omitempty"`
+	AllowedVendors   *jsonVendors     `json:"allowedVendors,omitempty"`
+	PublisherTC      *jsonPublisherTC `json:"publisherTC,omitempty"`
+}
+
+// jsonConsentV1 is the JSON representation of a TCF 1.0 consent.
+type jsonConsentV1 struct {
+	jsonHeader
+	Purposes jsonConsents       `json:"purposes"`
+	Vendors  jsonVendorConsents `json:"vendors"`
+}
+
+// jsonConsentV2 is the JSON representation of a TCF 2.0 consent.
+type jsonConsentV2 struct {
+	jsonHeader
+	TCFPolicyVersion      int                `json:"tcfPolicyVersion"`
+	IsServiceSpecific     bool               `json:"isServiceSpecific"`
+	UseNonStandardStacks  bool               `json:"useNonStandardStacks"`
+	PurposeOneTreatment   bool               `json:"purposeOneTreatment"`
+	PublisherCC           string             `json:"publisherCC"`
+	Purposes              jsonLegalBases     `json:"purposes"`
+	SpecialFeatureOptIns  []int              `json:"specialFeatureOptIns"`
+	Vendors               jsonVendorSections `json:"vendors"`
+	PublisherRestrictions []jsonRestriction  `json:"publisherRestrictions"`
+	Segments              jsonSegments       `json:"segments"`
+}
+
+// MarshalJSON returns the consent following the JSONSchemaVersion schema.
+func (c *ConsentV1) MarshalJSON() ([]byte, error) {
+	p := c.parsedConsent
+	vendors := bitset{}
+	if p.IsRangeEncoding {
+		vendors = c.rangeVendors()
+	} else {
+		for vendorID, allowed := range p.ConsentedVendors {
+			if allowed {
+				vendors.setRange(vendorID, vendorID)
+			}
+		}
+	}
+	return json.Marshal(jsonConsentV1{
+		jsonHeader: jsonHeader{
+			jsonVersion:       jsonVersion{SchemaVersion: JSONSchemaVersion, TCFVersion: c.Version()},
+			Created:           p.Created,
+			LastUpdated:       p.LastUpdated,
+			CMPID:             p.CMPID,
+			CMPVersion:        p.CMPVersion,
+			ConsentScreen:     p.ConsentScreen,
+			ConsentLanguage:   p.ConsentLanguage,
+			VendorListVersion: p.VendorListVersion,
+		},
+		Purposes: jsonConsents{Consents: idsFromMap(p.PurposesAllowed)},
+		Vendors: jsonVendorConsents{
+			Consents: jsonVendors{
+				MaxVendorID:     p.MaxVendorID,
+				IsRangeEncoding: p.IsRangeEncoding,
+				IDs:             collectIDs(vendors.upTo(p.MaxVendorID)),
+			},
+		},
+	})
+}
+
+// MarshalJSON returns the consent following the JSONSchemaVersion schema, or the error
+// found decoding the vendor sections of lazily decoded consents.
+func (c *ConsentV2) MarshalJSON() ([]byte, error) {
+	if err := c.Err(); err != nil {
+		return nil, err
+	}
+	consent := jsonConsentV2{
+		jsonHeader: jsonHeader{
+			jsonVersion:       jsonVersion{SchemaVersion: JSONSchemaVersion, TCFVersion: c.Version()},
+			Created:           c.created,
+			LastUpdated:       c.lastUpdated,
+			CMPID:             c.cmpID,
+			CMPVersion:        c.cmpVersion,
+			ConsentScreen:     c.consentScreen,
+			ConsentLanguage:   c.ConsentLanguage(),
+			VendorListVersion: c.vendorListVersion,
+		},
+		TCFPolicyVersion:     c.tcfPolicyVersion,
+		IsServiceSpecific:    c.isServiceSpecific,
+		UseNonStandardStacks: c.useNonStandardStacks,
+		PurposeOneTreatment:  c.purposeOneTreatment,
+		PublisherCC:          c.PublisherCC(),
+		Purposes: jsonLegalBases{
+			Consents:            collectIDs(flagIDs(c.purposesConsent)),
+			LegitimateInterests: collectIDs(flagIDs(c.purposesLI)),
+		},
+		SpecialFeatureOptIns: collectIDs(flagIDs(c.specialFeatures)),
+		Vendors: jsonVendorSections{
+			Consents: jsonVendors{
+				MaxVendorID:     c.maxConsentVendorID,
+				IsRangeEncoding: c.isConsentRange,
+				IDs:             collectIDs(c.consentedVendors.all()),
+			},
+			LegitimateInterests: jsonVendors{
+				MaxVendorID:     c.maxInterestsVendorID,
+				IsRangeEncoding: c.isInterestsRange,
+				IDs:             collectIDs(c.interestsVendors.all()),
+			},
+		},
+		PublisherRestrictions: []jsonRestriction{},
+		Segments: jsonSegments{
+			DisclosedVendors: newJSONVendorList(c.disclosedVendors),
+			AllowedVendors:   newJSONVendorList(c.allowedVendors),
+		},
+	}
+	for _, restriction := range c.restrictions {
+		ranges := make([]jsonRange, len(restriction.RestrictionsRange))
+		for i, entry := range restriction.RestrictionsRange {
+			ranges[i] = jsonRange{StartVendorID: entry.StartVendorID, EndVendorID: entry.EndVendorID}
+		}
+		consent.PublisherRestrictions = append(consent.PublisherRestrictions, jsonRestriction{
+			PurposeID:       restriction.PurposeID,
+			RestrictionType: int(restriction.RestrictionType),
+			VendorRanges:    ranges,
+		})
+	}
+	if entry := c.publisherTC; entry != nil {
+		consent.Segments.PublisherTC = &jsonPublisherTC{
+			Purposes: jsonLegalBases{
+				Consents:            idsFromMap(entry.PubPurposesConsent),
+				LegitimateInterests: idsFromMap(entry.PubPurposesLITransparency),
+			},
+			NumCustomPurposes: entry.NumCustomPurposes,
+			CustomPurposes: jsonLegalBases{
+				Consents:            idsFromMap(entry.CustomPurposesConsent),
+				LegitimateInterests: idsFromMap(entry.CustomPurposesLITransparency),
+			},
+		}
+	}
+	return json.Marshal(consent)
+}
+
+// Unmarshal returns the consent of its JSON representation, following the JSONSchemaVersion
+// schema. It returns ErrUnsupportedSchema with other schema versions, ErrInvalidVersion with
+// unknown TCF versions, ErrValueOutOfRange with IDs, restriction types or letters that can't
+// be encoded and ErrInvalidRange with vendor ranges ending before their start.
+func Unmarshal(data []byte) (Consent, error) {
+	version := jsonVersion{}
+	if err := json.Unmarshal(data, &version); err != nil {
+		return nil, err
+	}
+	if version.SchemaVersion != JSONSchemaVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedSchema, version.SchemaVersion)
+	}
+	switch iabconsent.TCFVersion(version.TCFVersion) {
+	case iabconsent.V1:
+		consent := jsonConsentV1{}
+		if err := json.Unmarshal(data, &consent); err != nil {
+			return nil, err
+		}
+		return consent.toConsent()
+	case iabconsent.V2:
+		consent := jsonConsentV2{}
+		if err := json.Unmarshal(data, &consent); err != nil {
+			return nil, err
+		}
+		return consent.toConsent()
+	}
+	return nil, ErrInvalidVersion
+}
+
+// toConsent returns the TCF 1.0 consent of the JSON representation.
+func (j jsonConsentV1) toConsent() (Consent, error) {
+	vendors := j.Vendors.Consents
+	if err := checkLetters(j.ConsentLanguage, "consent language"); err != nil {
+		return nil, err
+	}
+	if err := checkIDs(j.Purposes.Consents, maxPurposeID, "purpose"); err != nil {
+		return nil, err
+	}
+	if err := checkIDs(vendors.IDs, vendors.MaxVendorID, "vendor"); err != nil {
+		return nil, err
+	}
+	p := &iabconsent.ParsedConsent{
+		Version:           int(iabconsent.V1),
+		Created:           j.Created,
+		LastUpdated:       j.LastUpdated,
+		CMPID:             j.CMPID,
+		CMPVersion:        j.CMPVersion,
+		ConsentScreen:     j.ConsentScreen,
+		ConsentLanguage:   j.ConsentLanguage,
+		VendorListVersion: j.VendorListVersion,
+		PurposesAllowed:   mapFromIDs(j.Purposes.Consents),
+		MaxVendorID:       vendors.MaxVendorID,
+		IsRangeEncoding:   vendors.IsRangeEncoding,
+	}
+	if vendors.IsRangeEncoding {
+		p.RangeEntries = NewVendorSet(vendors.IDs...).vendors.ranges()
+		p.NumEntries = len(p.RangeEntries)
+	} else {
+		p.ConsentedVendors = mapFromIDs(vendors.IDs)
+	}
+	return &ConsentV1{parsedConsent: p}, nil
+}
+
+// toConsent returns the TCF 2.0 consent of the JSON representation.
+func (j jsonConsentV2) toConsent() (Consent, error) {
+	interests := j.Vendors.LegitimateInterests
+	checks := []struct {
+		ids   []int
+		max   int
+		field string
+	}{
+		{j.Purposes.Consents, maxPurposeID, "purpose"},
+		{j.Purposes.LegitimateInterests, maxPurposeID, "purpose"},
+		{j.SpecialFeatureOptIns, maxSpecialFeatureID, "special feature"},
+		{j.Vendors.Consents.IDs, j.Vendors.Consents.MaxVendorID, "vendor"},
+		{interests.IDs, interests.MaxVendorID, "vendor"},
+	}
+	for _, check := range checks {
+		if err := checkIDs(check.ids, check.max, check.field); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkLetters(j.ConsentLanguage, "consent language"); err != nil {
+		return nil, err
+	}
+	if err := checkLetters(j.PublisherCC, "publisher country code"); err != nil {
+		return nil, err
+	}
+	p := &iabconsent.V2ParsedConsent{
+		Version:                  int(iabconsent.V2),
+		Created:                  j.Created,
+		LastUpdated:              j.LastUpdated,
+		CMPID:                    j.CMPID,
+		CMPVersion:               j.CMPVersion,
+		ConsentScreen:            j.ConsentScreen,
+		ConsentLanguage:          j.ConsentLanguage,
+		VendorListVersion:        j.VendorListVersion,
+		TCFPolicyVersion:         j.TCFPolicyVersion,
+		IsServiceSpecific:        j.IsServiceSpecific,
+		UseNonStandardStacks:     j.UseNonStandardStacks,
+		SpecialFeaturesOptIn:     mapFromIDs(j.SpecialFeatureOptIns),
+		PurposesConsent:          mapFromIDs(j.Purposes.Consents),
+		PurposesLITransparency:   mapFromIDs(j.Purposes.LegitimateInterests),
+		PurposeOneTreatment:      j.PurposeOneTreatment,
+		PublisherCC:              j.PublisherCC,
+		MaxConsentVendorID:       j.Vendors.Consents.MaxVendorID,
+		IsConsentRangeEncoding:   j.Vendors.Consents.IsRangeEncoding,
+		ConsentedVendors:         mapFromIDs(j.Vendors.Consents.IDs),
+		MaxInterestsVendorID:     interests.MaxVendorID,
+		IsInterestsRangeEncoding: interests.IsRangeEncoding,
+		InterestsVendors:         mapFromIDs(interests.IDs),
+		OOBDisclosedVendors:      j.Segments.DisclosedVendors.toVendorList(iabconsent.DisclosedVendors),
+		OOBAllowedVendors:        j.Segments.AllowedVendors.toVendorList(iabconsent.AllowedVendors),
+	}
+	for _, restriction := range j.PublisherRestrictions {
+		entries, err := restriction.toRangeEntries()
+		if err != nil {
+			return nil, err
+		}
+		p.PubRestrictionEntries = append(p.PubRestrictionEntries, &iabconsent.PubRestrictionEntry{
+			PurposeID:         restriction.PurposeID,
+			RestrictionType:   iabconsent.RestrictionType(restriction.RestrictionType),
+			NumEntries:        len(entries),
+			RestrictionsRange: entries,
+		})
+	}
+	p.NumPubRestrictions = len(p.PubRestrictionEntries)
+	if entry := j.Segments.PublisherTC; entry != nil {
+		p.PublisherTCEntry = &iabconsent.PublisherTCEntry{
+			SegmentType:                  iabconsent.PublisherTC,
+			PubPurposesConsent:           mapFromIDs(entry.Purposes.Consents),
+			PubPurposesLITransparency:    mapFromIDs(entry.Purposes.LegitimateInterests),
+			NumCustomPurposes:            entry.NumCustomPurposes,
+			CustomPurposesConsent:        mapFromIDs(entry.CustomPurposes.Consents),
+			CustomPurposesLITransparency: mapFromIDs(entry.CustomPurposes.LegitimateInterests),
+		}
+	}
+	return newConsentV2FromParsed(p), nil
+}
+
+// toRangeEntries returns the vendor ranges of the publisher restriction, with an error if
+// its purpose, its type or any of its ranges can't be encoded.
+func (j jsonRestriction) toRangeEntries() ([]*iabconsent.RangeEntry, error) {
+	if err := checkIDs([]int{j.PurposeID}, maxRestrictionPurposeID, "restriction purpose"); err != nil {
+		return nil, err
+	}
+	if j.RestrictionType < 0 || j.RestrictionType > maxRestrictionType {
+		return nil, fmt.Errorf("%w: restriction type %d must be between 0 and %d",
+			ErrValueOutOfRange, j.RestrictionType, maxRestrictionType)
+	}
+	entries := make([]*iabconsent.RangeEntry, len(j.VendorRanges))
+	for i, r := range j.VendorRanges {
+		if err := checkRange(r.StartVendorID, r.EndVendorID, maxVendorIDLimit); err != nil {
+			return nil, err
+		}
+		entries[i] = &iabconsent.RangeEntry{StartVendorID: r.StartVendorID, EndVendorID: r.EndVendorID}
+	}
+	return entries, nil
+}
+
+// newJSONVendorList returns the JSON representation of the vendor list of a segment, if any.
+func newJSONVendorList(vendors *iabconsent.OOBVendorList) *jsonVendors {
+	if vendors == nil {
+		return nil
+	}
+	return &jsonVendors{
+		MaxVendorID:     vendors.MaxVendorID,
+		IsRangeEncoding: vendors.IsRangeEncoding,
+		IDs:             collectIDs(bitsetFromParsed(vendors.MaxVendorID, vendors.Vendors, vendors.VendorEntries).all()),
+	}
+}
+
+// toVendorList returns the vendor list of a segment of its JSON representation, if any.
+func (j *jsonVendors) toVendorList(segmentType iabconsent.SegmentType) *iabconsent.OOBVendorList {
+	if j == nil {
+		return nil
+	}
+	vendors := &iabconsent.OOBVendorList{
+		SegmentType:     segmentType,
+		MaxVendorID:     j.MaxVendorID,
+		IsRangeEncoding: j.IsRangeEncoding,
+	}
+	if j.IsRangeEncoding {
+		vendors.VendorEntries = NewVendorSet(j.IDs...).vendors.ranges()
+		vendors.NumEntries = len(vendors.VendorEntries)
+	} else {
+		vendors.Vendors = mapFromIDs(j.IDs)
+	}
+	return vendors
+}
+
+// checkLetters returns an error if the value is not made of two letters from A to Z, the
+// only ones the consent strings can hold.
+func checkLetters(value string, field string) error {
+	if len(value) != 2 || strings.Trim(value, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return fmt.Errorf("%w: %s %q must have 2 uppercase letters", ErrValueOutOfRange, field, value)
+	}
+	return nil
+}
+
+// checkIDs returns an error if any of the IDs is not between 1 and max.
+func checkIDs(ids []int, max int, field string) error {
+	for _, id := range ids {
+		if id < 1 || id > max {
+			return fmt.Errorf("%w: %s ID %d must be between 1 and %d", ErrValueOutOfRange, field, id, max)
+		}
+	}
+	return nil
+}
+
+// collectIDs returns the IDs of the iterator, as an empty list if there are none.
+func collectIDs(ids iter.Seq[int]) []int {
+	return slices.AppendSeq([]int{}, ids)
+}
+
+// flagIDs returns an iterator over the IDs set in the mask.
+func flagIDs(flags uint32) iter.Seq[int] {
+	return bitset{uint64(flags)}.all()
+}
+
+// upTo returns an iterator over the IDs of the set from 1 to n.
+func (b bitset) upTo(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for id := range b.all() {
+			if id > n || !yield(id) {
+				return
+			}
+		}
+	}
+}
+
+// idsFromMap returns the IDs set in the map, sorted.
+func idsFromMap(values map[int]bool) []int {
+	ids := []int{}
+	for id, value := range values {
+		if value {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// mapFromIDs returns the IDs as a map, as returned by the iabconsent reader.
+func mapFromIDs(ids []int) map[int]bool {
+	values := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		values[id] = true
+	}
+	return values
+}
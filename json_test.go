@@ -0,0 +1,131 @@
+package iab_tcf_test
+
+import (
+	"encoding/json"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSON", func() {
+
+	const (
+		testRestrictedConsent = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"
+		testConsentV1         = "BOlLbqtOlLbqtAVABADECg-AAAApp7v______9______9uz_Ov_v_f__33e8__9v_l_7_-___u_-3zd4u_1vf99yfm1-7etr3tp_87ues2_Xur__79__3z3_9phP78k89r7337Ew-v02"
+	)
+
+	It("marshals TCF 2.0 consents following the schema", func() {
+		consent, err := iab_tcf.NewConsent(testRestrictedConsent)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := json.Marshal(consent)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"schemaVersion": 1,
+			"tcfVersion": 2,
+			"created": "2017-07-14T02:40:00Z",
+			"lastUpdated": "2017-07-14T02:40:00Z",
+			"cmpId": 10,
+			"cmpVersion": 1,
+			"consentScreen": 1,
+			"consentLanguage": "EN",
+			"vendorListVersion": 50,
+			"tcfPolicyVersion": 2,
+			"isServiceSpecific": false,
+			"useNonStandardStacks": false,
+			"purposeOneTreatment": false,
+			"publisherCC": "EN",
+			"purposes": {"consents": [1, 2, 3, 4], "legitimateInterests": []},
+			"specialFeatureOptIns": [1],
+			"vendors": {
+				"consents": {"maxVendorId": 10, "isRangeEncoding": false, "ids": [5]},
+				"legitimateInterests": {"maxVendorId": 0, "isRangeEncoding": false, "ids": []}
+			},
+			"publisherRestrictions": [{
+				"purposeId": 1,
+				"restrictionType": 0,
+				"vendorRanges": [{"startVendorId": 5, "endVendorId": 5}]
+			}],
+			"segments": {}
+		}`))
+	})
+
+	It("marshals TCF 1.0 consents following the schema", func() {
+		consent, err := iab_tcf.NewConsent(testConsentV1)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := json.Marshal(consent)
+		Expect(err).NotTo(HaveOccurred())
+		decoded := map[string]interface{}{}
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+		Expect(decoded).To(HaveKeyWithValue("schemaVersion", BeNumerically("==", 1)))
+		Expect(decoded).To(HaveKeyWithValue("tcfVersion", BeNumerically("==", 1)))
+		Expect(decoded).NotTo(HaveKey("publisherRestrictions"))
+		Expect(decoded).NotTo(HaveKey("segments"))
+		Expect(decoded["vendors"]).NotTo(HaveKey("legitimateInterests"))
+	})
+
+	DescribeTable("unmarshals the same consent that was marshalled",
+		func(value string) {
+			consent, err := iab_tcf.NewConsent(value)
+			Expect(err).NotTo(HaveOccurred())
+			data, err := json.Marshal(consent)
+			Expect(err).NotTo(HaveOccurred())
+			unmarshalled, err := iab_tcf.Unmarshal(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(iab_tcf.Diff(consent, unmarshalled).IsEmpty()).To(BeTrue())
+			Expect(unmarshalled.GetConsentBitstring()).To(Equal(consent.GetConsentBitstring()))
+			again, err := json.Marshal(unmarshalled)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(again).To(MatchJSON(data))
+		},
+		Entry("with a bit field", "COxR03kOxR1CqBcABCENAgCMAP_AAH_AAAqIF3EXySoGY2thI2YVFxBEIYwfJxyigMgChgQIsSwNQIeFLBoGLiAAHBGYJAQAGBAEEACBAQIkHGBMCQAAgAgBiRCMQEGMCzNIBIBAggEbY0FACCVmHkHSmZCY7064O__QLuIJEFQMAkSBAIACLECIQwAQDiAAAYAlAAABAhIaAAgIWBQEeAAAACAwAAgAAABBAAACAAQAAICIAAABAAAgAiAQAAAAGgIQAACBABACRIAAAEANCAAgiCEAQg4EAo4AAA"),
+		Entry("with ranges and a disclosed vendors segment", "COytyllOytyllCrAAAENAiCMAFVAACqAAAAAF3QAgAFABkAAoioAAA.IF5EX2S5OI2tho2YdF7BEYYwfJxyigMgShgQIsS8NwIeFbBoGPmAAHBG4JAQAGBAkkACBAQIsHGBcCQABgIgRiRCMQEGMjzNKBJBAggkbI0FACCVmnkHS3ZCY70-6u__bA"),
+		Entry("with publisher restrictions", testRestrictedConsent),
+		Entry("with TCF 1.0", testConsentV1),
+	)
+
+	It("encodes the unmarshalled consents back to the same string", func() {
+		consent, err := iab_tcf.NewConsent(testRestrictedConsent)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := json.Marshal(consent)
+		Expect(err).NotTo(HaveOccurred())
+		unmarshalled, err := iab_tcf.Unmarshal(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iab_tcf.EncodeV2(unmarshalled.(*iab_tcf.ConsentV2).ToParsedConsent())).To(Equal(testRestrictedConsent))
+	})
+
+	DescribeTable("returns an error with invalid data",
+		func(data string, expected error) {
+			_, err := iab_tcf.Unmarshal([]byte(data))
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("with an unsupported schema", `{"schemaVersion": 2, "tcfVersion": 2}`, iab_tcf.ErrUnsupportedSchema),
+		Entry("with an invalid TCF version", `{"schemaVersion": 1, "tcfVersion": 3}`, iab_tcf.ErrInvalidVersion),
+		Entry("with a purpose out of range", `{"schemaVersion": 1, "tcfVersion": 2, "purposes": {"consents": [25]}}`, iab_tcf.ErrValueOutOfRange),
+		Entry("with a vendor out of range", `{"schemaVersion": 1, "tcfVersion": 1, "vendors": {"consents": {"maxVendorId": 4, "ids": [5]}}}`, iab_tcf.ErrValueOutOfRange),
+		Entry("with a lowercase consent language", `{"schemaVersion": 1, "tcfVersion": 1, "consentLanguage": "en"}`, iab_tcf.ErrValueOutOfRange),
+		Entry("with a publisher country code too long", `{"schemaVersion": 1, "tcfVersion": 2, "consentLanguage": "EN", "publisherCC": "ESP"}`, iab_tcf.ErrValueOutOfRange),
+		Entry("with a restriction purpose out of range", restrictionJSON(`{"purposeId": 64, "restrictionType": 0}`), iab_tcf.ErrValueOutOfRange),
+		Entry("with a restriction type out of range", restrictionJSON(`{"purposeId": 1, "restrictionType": 4}`), iab_tcf.ErrValueOutOfRange),
+		Entry("with a restriction range ending before its start", restrictionJSON(`{"purposeId": 1, "restrictionType": 0, "vendorRanges": [{"startVendorId": 5, "endVendorId": 3}]}`), iab_tcf.ErrInvalidRange),
+		Entry("with a restriction range past the maximum vendor ID", restrictionJSON(`{"purposeId": 1, "restrictionType": 0, "vendorRanges": [{"startVendorId": 5, "endVendorId": 65536}]}`), iab_tcf.ErrInvalidRange),
+	)
+
+	It("returns the error of the vendor sections of lazily decoded consents", func() {
+		consent, err := iab_tcf.NewConsent("CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAho", iab_tcf.WithLazyDecoding())
+		Expect(err).NotTo(HaveOccurred())
+		_, err = json.Marshal(consent)
+		Expect(err).To(MatchError(iab_tcf.ErrUnexpectedEnd))
+	})
+
+	It("returns an error with invalid JSON", func() {
+		_, err := iab_tcf.Unmarshal([]byte("{"))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+// restrictionJSON returns a TCF 2.0 consent with the publisher restriction received.
+func restrictionJSON(restriction string) string {
+	return `{"schemaVersion": 1, "tcfVersion": 2, "consentLanguage": "EN", "publisherCC": "ES", "publisherRestrictions": [` +
+		restriction + `]}`
+}
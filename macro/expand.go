@@ -0,0 +1,138 @@
+package macro
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+)
+
+const (
+	// GDPR is the macro replaced with "1" if GDPR applies, "0" if it doesn't and empty if unknown.
+	GDPR = "${GDPR}"
+	// GDPRConsentPrefix is the prefix of the per vendor macro replaced with the TC string,
+	// e.g. `${GDPR_CONSENT_755}`.
+	GDPRConsentPrefix = "${GDPR_CONSENT_"
+	// AdditionalConsent is the macro replaced with the Google Additional Consent string.
+	AdditionalConsent = "${ADDTL_CONSENT}"
+)
+
+// ErrMissingConsentString is returned when a template has `${GDPR_CONSENT_XXXX}` macros
+// for a consent, but neither the options nor the consent have the raw consent string.
+var ErrMissingConsentString = errors.New("Missing consent string to expand the consent macros")
+
+// consentString is implemented by the consents keeping the string they were decoded
+// from, such as the ones returned by iab_tcf.NewConsent.
+type consentString interface {
+	ConsentString() string
+}
+
+// macroRegexp matches the TCF macros, capturing the vendor ID of `${GDPR_CONSENT_XXXX}`.
+var macroRegexp = regexp.MustCompile(`\$\{(?:GDPR|GDPR_CONSENT_(\d+)|ADDTL_CONSENT)\}`)
+
+// Option is the type that allows us to configure the Expander dynamically.
+type Option func(expander *Expander)
+
+// Expander is the type that contains the values to fill the TCF macros of URL templates with.
+type Expander struct {
+	// GDPRApplies is nil if it's unknown whether GDPR applies.
+	GDPRApplies         *bool
+	ConsentString       string
+	AdditionalConsent   string
+	BlankWithoutConsent bool
+}
+
+// WithGDPR allows to set whether GDPR applies. Otherwise it's considered to apply if
+// there's a consent, and to be unknown if there isn't.
+func WithGDPR(applies bool) Option {
+	return func(expander *Expander) {
+		expander.GDPRApplies = &applies
+	}
+}
+
+// WithConsentString allows to set the raw TC string the consent was parsed from, needed
+// for the consents not keeping it, e.g. the ones created from a reader.
+func WithConsentString(value string) Option {
+	return func(expander *Expander) {
+		expander.ConsentString = value
+	}
+}
+
+// WithAdditionalConsent allows to set the Google Additional Consent string.
+func WithAdditionalConsent(value string) Option {
+	return func(expander *Expander) {
+		expander.AdditionalConsent = value
+	}
+}
+
+// WithBlankWithoutConsent makes the expander return an empty URL when GDPR applies and
+// any of the vendors of its `${GDPR_CONSENT_XXXX}` macros lacks consent.
+func WithBlankWithoutConsent() Option {
+	return func(expander *Expander) {
+		expander.BlankWithoutConsent = true
+	}
+}
+
+// NewExpander returns an expander instance.
+func NewExpander(options ...Option) *Expander {
+	expander := &Expander{}
+	for _, option := range options {
+		option(expander)
+	}
+	return expander
+}
+
+// Expand replaces the TCF macros of the template with the values of the consent received,
+// leaving any other macro untouched. The `${GDPR_CONSENT_XXXX}` macros are replaced with
+// the consent string of the options or else the one the consent was decoded from, and
+// ErrMissingConsentString is returned if there's a consent but neither has it.
+func (expander *Expander) Expand(template string, consent iab_tcf.Consent) (string, error) {
+	value := expander.ConsentString
+	if raw, ok := consent.(consentString); ok && value == "" {
+		value = raw.ConsentString()
+	}
+	gdprApplies := consent != nil
+	if expander.GDPRApplies != nil {
+		gdprApplies = *expander.GDPRApplies
+	}
+	if expander.BlankWithoutConsent && gdprApplies {
+		for _, match := range macroRegexp.FindAllStringSubmatch(template, -1) {
+			if match[1] == "" {
+				continue
+			}
+			vendorID, err := strconv.Atoi(match[1])
+			if err != nil || consent == nil || !consent.HasUserConsented(vendorID) {
+				return "", nil
+			}
+		}
+	}
+	if consent != nil && value == "" {
+		for _, match := range macroRegexp.FindAllStringSubmatch(template, -1) {
+			if match[1] != "" {
+				return "", ErrMissingConsentString
+			}
+		}
+	}
+	return macroRegexp.ReplaceAllStringFunc(template, func(macro string) string {
+		switch macro {
+		case GDPR:
+			if expander.GDPRApplies == nil && consent == nil {
+				return ""
+			}
+			if gdprApplies {
+				return "1"
+			}
+			return "0"
+		case AdditionalConsent:
+			return expander.AdditionalConsent
+		}
+		return value
+	}), nil
+}
+
+// Expand replaces the TCF macros of the template with the values of the consent received
+// using an expander configured with the options passed as parameter.
+func Expand(template string, consent iab_tcf.Consent, options ...Option) (string, error) {
+	return NewExpander(options...).Expand(template, consent)
+}
@@ -0,0 +1,84 @@
+package macro_test
+
+import (
+	"github.com/LiveRamp/iabconsent"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/macro"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Expand", func() {
+
+	const (
+		testConsent  = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAAAA"
+		testTemplate = "https://example.com/p?gdpr=${GDPR}&gdpr_consent=${GDPR_CONSENT_5}&addtl_consent=${ADDTL_CONSENT}&cb=${CACHEBUSTER}"
+	)
+
+	var consent iab_tcf.Consent
+
+	BeforeEach(func() {
+		var err error
+		consent, err = iab_tcf.NewConsent(testConsent)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("replaces the TCF macros", func() {
+		url, err := macro.Expand(testTemplate, consent, macro.WithConsentString(testConsent), macro.WithAdditionalConsent("1~1.35"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(url).To(Equal("https://example.com/p?gdpr=1&gdpr_consent=" + testConsent + "&addtl_consent=1~1.35&cb=${CACHEBUSTER}"))
+	})
+
+	It("uses the consent string the consent was decoded from", func() {
+		Expect(macro.Expand("gdpr_consent=${GDPR_CONSENT_5}", consent)).To(Equal("gdpr_consent=" + testConsent))
+	})
+
+	It("returns an error without the consent string", func() {
+		reader := iabconsent.NewConsentReader(must(iab_tcf.DecodeConsent(testConsent)))
+		iab_tcf.GetVersion(reader)
+		parsed, err := iab_tcf.NewConsentV2(reader)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = macro.Expand("gdpr=${GDPR}&gdpr_consent=${GDPR_CONSENT_5}", parsed)
+		Expect(err).To(MatchError(macro.ErrMissingConsentString))
+		Expect(macro.Expand("gdpr=${GDPR}", parsed)).To(Equal("gdpr=1"))
+	})
+
+	It("leaves the GDPR macro empty when unknown", func() {
+		Expect(macro.Expand("gdpr=${GDPR}&gdpr_consent=${GDPR_CONSENT_5}", nil)).To(Equal("gdpr=&gdpr_consent="))
+	})
+
+	It("uses the GDPR flag received", func() {
+		Expect(macro.Expand("gdpr=${GDPR}", consent, macro.WithGDPR(false))).To(Equal("gdpr=0"))
+		Expect(macro.Expand("gdpr=${GDPR}", nil, macro.WithGDPR(true))).To(Equal("gdpr=1"))
+	})
+
+	Context("blanking URLs without consent", func() {
+
+		It("keeps the URLs of the vendors with consent", func() {
+			expander := macro.NewExpander(macro.WithConsentString(testConsent), macro.WithBlankWithoutConsent())
+			Expect(expander.Expand("c=${GDPR_CONSENT_5}", consent)).To(Equal("c=" + testConsent))
+		})
+
+		It("blanks the URLs of the vendors without consent", func() {
+			expander := macro.NewExpander(macro.WithConsentString(testConsent), macro.WithBlankWithoutConsent())
+			Expect(expander.Expand("a=${GDPR_CONSENT_5}&b=${GDPR_CONSENT_6}", consent)).To(BeEmpty())
+		})
+
+		It("blanks the URLs when GDPR applies without consent", func() {
+			expander := macro.NewExpander(macro.WithGDPR(true), macro.WithBlankWithoutConsent())
+			Expect(expander.Expand("c=${GDPR_CONSENT_5}", nil)).To(BeEmpty())
+		})
+
+		It("keeps the URLs when GDPR doesn't apply", func() {
+			expander := macro.NewExpander(macro.WithGDPR(false), macro.WithBlankWithoutConsent())
+			Expect(expander.Expand("c=${GDPR_CONSENT_6}", consent)).To(Equal("c=" + testConsent))
+		})
+	})
+})
+
+// must returns the value received, failing the test if there's an error.
+func must[T any](value T, err error) T {
+	Expect(err).NotTo(HaveOccurred())
+	return value
+}
@@ -8,10 +8,8 @@ import (
 	"github.com/LiveRamp/iabconsent"
 )
 
-var booleanFormatter = map[bool]string{
-	true:  "1",
-	false: "0",
-}
+// ErrInvalidVersion is returned when the consent string is neither TCF v1 nor v2.
+var ErrInvalidVersion = errors.New("Invalid consent version found")
 
 // Consent is an interface to retrieve the most important information
 // from consent strings, no matter if they are v1 or v2.
@@ -57,12 +55,32 @@ type Consent interface {
 	IsCMPValid() bool
 }
 
+// SpecialFeatureConsent is implemented by the consents with the opt-ins of the Special
+// Features, such as the TCF 2.0 ones. It's not part of Consent so the implementations of
+// Consent outside this package don't need to implement it.
+type SpecialFeatureConsent interface {
+	// HasSpecialFeatureOptIn returns true if the user opted in to the Special Feature passed
+	// as parameter, e.g. 1 to use precise geolocation data.
+	HasSpecialFeatureOptIn(featureID int) bool
+}
+
+// ConsentErr returns the error found decoding the consent after it was created, e.g. in the
+// vendor sections of the TCF 2.0 consents decoded with WithLazyDecoding, decoding them first
+// if needed. It returns nil for the consents without such errors, including the ones
+// implemented outside this package.
+func ConsentErr(consent Consent) error {
+	if c, ok := consent.(interface{ Err() error }); ok {
+		return c.Err()
+	}
+	return nil
+}
+
 // DecodeConsent receives a GDPR IAB consent string and decodes the
 // CORE segment only, returning it. It also returns an error if something
 // happened and we couldn't decode it.
 func DecodeConsent(consent string) ([]byte, error) {
-	segments := strings.Split(consent, ".")
-	decoded, err := base64.RawURLEncoding.DecodeString(segments[0])
+	core, _, _ := strings.Cut(consent, ".")
+	decoded, err := base64.RawURLEncoding.DecodeString(core)
 	if err != nil {
 		return nil, err
 	}
@@ -80,17 +98,12 @@ func GetVersion(r *iabconsent.ConsentReader) iabconsent.TCFVersion {
 
 // NewConsent returns a Consent instance with all the necessary information
 // available. It returns an error if something went wrong.
-func NewConsent(consent string) (Consent, error) {
-	decoded, err := DecodeConsent(consent)
-	if err == nil {
-		reader := iabconsent.NewConsentReader(decoded)
-		switch GetVersion(reader) {
-		case iabconsent.V1:
-			return NewConsentV1(reader)
-		case iabconsent.V2:
-			return NewConsentV2(reader)
-		}
-		return nil, errors.New("Invalid consent version found")
+// TCF 2.0 consent strings are decoded with the native parser of DecodeConsentV2, and
+// can be lazily decoded with the WithLazyDecoding option.
+func NewConsent(consent string, options ...Option) (Consent, error) {
+	if len(options) == 0 {
+		// Avoids allocating a decoder for every consent string in the common case.
+		return defaultDecoder.Decode(consent)
 	}
-	return nil, err
+	return NewDecoder(options...).Decode(consent)
 }
@@ -0,0 +1,115 @@
+package openrtb
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/gpp"
+	"github.com/hybridtheory/iab-tcf/resolver"
+)
+
+// bidRequest contains only the privacy related fields of an OpenRTB 2.x bid request,
+// both in their OpenRTB 2.6 location and in their OpenRTB 2.5 extensions.
+type bidRequest struct {
+	User *struct {
+		Consent string `json:"consent"`
+		Ext     *struct {
+			Consent string `json:"consent"`
+		} `json:"ext"`
+	} `json:"user"`
+	Regs *struct {
+		GDPR      *int   `json:"gdpr"`
+		USPrivacy string `json:"us_privacy"`
+		GPP       string `json:"gpp"`
+		GPPSID    []int  `json:"gpp_sid"`
+		Ext       *struct {
+			GDPR      *int   `json:"gdpr"`
+			USPrivacy string `json:"us_privacy"`
+		} `json:"ext"`
+	} `json:"regs"`
+}
+
+// Privacy contains the privacy signals found in a bid request.
+type Privacy struct {
+	// GDPR is the value of `regs.gdpr`, or nil if it's unknown.
+	GDPR *int
+	// GDPRApplies is true if `regs.gdpr` is 1 or the TCF EU v2 section is an applicable GPP section.
+	GDPRApplies bool
+	// ConsentString is the TC string found in `user.consent`, or in the GPP string if missing.
+	ConsentString string
+	// Consent is the parsed TC string, if any.
+	Consent iab_tcf.Consent
+	// USPrivacy is the value of `regs.us_privacy`.
+	USPrivacy string
+	// GPP is the value of `regs.gpp`.
+	GPP string
+	// GPPSID is the value of `regs.gpp_sid`.
+	GPPSID []int
+}
+
+// Extract finds the privacy signals in a raw bid request, looking first at the OpenRTB 2.6
+// fields and then at the OpenRTB 2.5 extensions, and parses the TC string found.
+// It returns an error without signals if the JSON is not valid, and an error with the
+// signals found if the TC string is not valid.
+func Extract(data []byte) (*Privacy, error) {
+	request := bidRequest{}
+	if err := json.Unmarshal(data, &request); err != nil {
+		return nil, err
+	}
+	privacy := &Privacy{}
+	if user := request.User; user != nil {
+		privacy.ConsentString = user.Consent
+		if privacy.ConsentString == "" && user.Ext != nil {
+			privacy.ConsentString = user.Ext.Consent
+		}
+	}
+	if regs := request.Regs; regs != nil {
+		privacy.GDPR = regs.GDPR
+		privacy.USPrivacy = regs.USPrivacy
+		privacy.GPP = regs.GPP
+		privacy.GPPSID = regs.GPPSID
+		if regs.Ext != nil {
+			if privacy.GDPR == nil {
+				privacy.GDPR = regs.Ext.GDPR
+			}
+			if privacy.USPrivacy == "" {
+				privacy.USPrivacy = regs.Ext.USPrivacy
+			}
+		}
+	}
+	privacy.GDPRApplies = (privacy.GDPR != nil && *privacy.GDPR == 1) || slices.Contains(privacy.GPPSID, gpp.TCFEUv2SID)
+	if privacy.ConsentString == "" && privacy.GPP != "" {
+		if gppConsent, err := gpp.NewConsent(privacy.GPP); err == nil {
+			privacy.ConsentString = gppConsent.Sections[gpp.TCFEUv2SID]
+		}
+	}
+	if privacy.ConsentString != "" {
+		consent, err := iab_tcf.NewConsent(privacy.ConsentString)
+		if err != nil {
+			return privacy, err
+		}
+		privacy.Consent = consent
+	}
+	return privacy, nil
+}
+
+// Signals returns the privacy signals found so they can be resolved with the resolver package.
+func (privacy *Privacy) Signals() resolver.Signals {
+	signals := resolver.Signals{
+		GDPRConsent: privacy.ConsentString,
+		USPrivacy:   privacy.USPrivacy,
+		GPP:         privacy.GPP,
+	}
+	if privacy.GDPR != nil {
+		signals.GDPR = strconv.Itoa(*privacy.GDPR)
+	}
+	sectionIDs := make([]string, len(privacy.GPPSID))
+	for i, sectionID := range privacy.GPPSID {
+		sectionIDs[i] = strconv.Itoa(sectionID)
+	}
+	signals.GPPSID = strings.Join(sectionIDs, ",")
+	return signals
+}
@@ -0,0 +1,91 @@
+package openrtb_test
+
+import (
+	"github.com/hybridtheory/iab-tcf/openrtb"
+	"github.com/hybridtheory/iab-tcf/resolver"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Extract", func() {
+
+	const (
+		testConsent    = "COyt4MbOyt4MbMOAAAENAiCgAIAAAAAAAAAAADEAAgIAAAAAAAA"
+		testGPPConsent = "DBACNY~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN"
+	)
+
+	var (
+		privacy *openrtb.Privacy
+		err     error
+	)
+
+	It("finds the OpenRTB 2.5 signals", func() {
+		privacy, err = openrtb.Extract([]byte(`{
+			"id": "1",
+			"user": {"id": "u", "ext": {"consent": "` + testConsent + `"}},
+			"regs": {"ext": {"gdpr": 1, "us_privacy": "1YNN"}}
+		}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*privacy.GDPR).To(Equal(1))
+		Expect(privacy.GDPRApplies).To(BeTrue())
+		Expect(privacy.ConsentString).To(Equal(testConsent))
+		Expect(privacy.Consent.CMPID()).To(Equal(782))
+		Expect(privacy.USPrivacy).To(Equal("1YNN"))
+	})
+
+	It("finds the OpenRTB 2.6 signals", func() {
+		privacy, err = openrtb.Extract([]byte(`{
+			"user": {"consent": "` + testConsent + `", "ext": {"consent": "invalid"}},
+			"regs": {"gdpr": 0, "us_privacy": "1YYN", "gpp": "` + testGPPConsent + `", "gpp_sid": [6], "ext": {"gdpr": 1}}
+		}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*privacy.GDPR).To(Equal(0))
+		Expect(privacy.GDPRApplies).To(BeFalse())
+		Expect(privacy.Consent.CMPID()).To(Equal(782))
+		Expect(privacy.USPrivacy).To(Equal("1YYN"))
+		Expect(privacy.GPP).To(Equal(testGPPConsent))
+		Expect(privacy.GPPSID).To(Equal([]int{6}))
+	})
+
+	It("takes the consent from the GPP string when missing", func() {
+		privacy, err = openrtb.Extract([]byte(`{"regs": {"gpp": "` + testGPPConsent + `", "gpp_sid": [2, 6]}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(privacy.GDPR).To(BeNil())
+		Expect(privacy.GDPRApplies).To(BeTrue())
+		Expect(privacy.Consent.CMPID()).To(Equal(31))
+	})
+
+	It("returns nothing without signals", func() {
+		privacy, err = openrtb.Extract([]byte(`{"id": "1", "imp": []}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(privacy.GDPRApplies).To(BeFalse())
+		Expect(privacy.Consent).To(BeNil())
+	})
+
+	It("returns the signals with invalid consent strings", func() {
+		privacy, err = openrtb.Extract([]byte(`{"user": {"consent": "invalid"}, "regs": {"gdpr": 1}}`))
+		Expect(err).To(HaveOccurred())
+		Expect(privacy.GDPRApplies).To(BeTrue())
+		Expect(privacy.Consent).To(BeNil())
+	})
+
+	It("fails with invalid JSON", func() {
+		privacy, err = openrtb.Extract([]byte(`{"user":`))
+		Expect(err).To(HaveOccurred())
+		Expect(privacy).To(BeNil())
+	})
+
+	It("converts the signals for the resolver", func() {
+		privacy, err = openrtb.Extract([]byte(`{
+			"user": {"consent": "` + testConsent + `"},
+			"regs": {"gdpr": 1, "gpp": "` + testGPPConsent + `", "gpp_sid": [2, 6]}
+		}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(privacy.Signals()).To(Equal(resolver.Signals{
+			GDPR:        "1",
+			GDPRConsent: testConsent,
+			GPP:         testGPPConsent,
+			GPPSID:      "2,6",
+		}))
+	})
+})
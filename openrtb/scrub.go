@@ -0,0 +1,151 @@
+package openrtb
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/netip"
+
+	"github.com/LiveRamp/iabconsent"
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+)
+
+const (
+	// PurposeStoreAccessInformation is the TCF purpose required to store and access
+	// information on the user device.
+	PurposeStoreAccessInformation = 1
+	// SpecialFeaturePreciseGeolocation is the TCF special feature required to use
+	// precise geolocation data.
+	SpecialFeaturePreciseGeolocation = 1
+)
+
+const (
+	// geoPrecision is the number of decimals kept in the coordinates without precise geolocation.
+	geoPrecision = 2
+	// ipv4Bits is the number of leading bits kept in IPv4 addresses without precise geolocation.
+	ipv4Bits = 24
+	// ipv6Bits is the number of leading bits kept in IPv6 addresses without precise geolocation.
+	ipv6Bits = 56
+)
+
+// deviceIDFields are the `device` fields that identify the user device.
+var deviceIDFields = []string{"ifa", "didsha1", "didmd5", "dpidsha1", "dpidmd5", "macsha1", "macmd5"}
+
+// userIDFields are the `user` and `user.ext` fields that identify the user through storage
+// on their device.
+var userIDFields = []string{"id", "buyeruid", "eids"}
+
+// Scrub returns a redacted copy of the raw bid request for the vendor passed as parameter,
+// following the TCF rules:
+//
+//   - Without consent for the vendor and for purpose 1, or if the publisher has flatly
+//     disallowed purpose 1 for the vendor or restricted it to legitimate interest, the
+//     user and device identifiers are removed, including the extended ones.
+//   - Without opt in for special feature 1 the `device.geo` and `user.geo` coordinates
+//     are truncated to two decimals and the device IP addresses are masked, by the
+//     family of each address.
+//
+// A nil consent is treated as a consent without any purpose or special feature.
+func Scrub(data []byte, consent iab_tcf.Consent, vendorID int) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	request := map[string]interface{}{}
+	if err := decoder.Decode(&request); err != nil {
+		return nil, err
+	}
+	device, _ := request["device"].(map[string]interface{})
+	user, _ := request["user"].(map[string]interface{})
+	if !canAccessDevice(consent, vendorID) {
+		deleteFields(device, deviceIDFields)
+		deleteFields(user, userIDFields)
+		ext, _ := user["ext"].(map[string]interface{})
+		deleteFields(ext, userIDFields)
+	}
+	if features, ok := consent.(iab_tcf.SpecialFeatureConsent); !ok || !features.HasSpecialFeatureOptIn(SpecialFeaturePreciseGeolocation) {
+		truncateGeo(device)
+		truncateGeo(user)
+		maskIP(device, "ip")
+		maskIP(device, "ipv6")
+	}
+	return json.Marshal(request)
+}
+
+// canAccessDevice returns true if the vendor can store and access information on the user
+// device. Purpose 1 can only be processed with consent, so restricting it to legitimate
+// interest disallows it like a flat restriction, while requiring consent keeps it allowed
+// with the consents checked.
+func canAccessDevice(consent iab_tcf.Consent, vendorID int) bool {
+	if consent == nil || !consent.HasUserConsented(vendorID) || !consent.HasConsentedPurpose(PurposeStoreAccessInformation) {
+		return false
+	}
+	for _, restriction := range consent.GetPublisherRestrictions() {
+		if restriction.PurposeID != PurposeStoreAccessInformation || !isRestricted(restriction.RestrictionsRange, vendorID) {
+			continue
+		}
+		switch restriction.RestrictionType {
+		case iabconsent.PurposeFlatlyNotAllowed, iabconsent.RequireLegitimateInterest:
+			return false
+		}
+	}
+	return true
+}
+
+// isRestricted returns true if the vendor is in any of the ranges of the restriction.
+func isRestricted(entries []*iabconsent.RangeEntry, vendorID int) bool {
+	for _, entry := range entries {
+		if vendorID >= entry.StartVendorID && vendorID <= entry.EndVendorID {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteFields removes the fields from the object, if any.
+func deleteFields(object map[string]interface{}, fields []string) {
+	for _, field := range fields {
+		delete(object, field)
+	}
+}
+
+// truncateGeo truncates the coordinates of the `geo` object of the object received, if any.
+func truncateGeo(object map[string]interface{}) {
+	geo, ok := object["geo"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, field := range []string{"lat", "lon"} {
+		value, ok := geo[field].(json.Number)
+		if !ok {
+			continue
+		}
+		if coordinate, err := value.Float64(); err == nil {
+			scale := math.Pow10(geoPrecision)
+			geo[field] = math.Round(coordinate*scale) / scale
+		}
+	}
+}
+
+// maskIP keeps only the leading bits of the IP address found in the field, if any, by its
+// family: IPv4 addresses, including the IPv4-mapped IPv6 ones, keep ipv4Bits of the IPv4
+// address and the rest ipv6Bits.
+func maskIP(object map[string]interface{}, field string) {
+	value, ok := object[field].(string)
+	if !ok {
+		return
+	}
+	address, err := netip.ParseAddr(value)
+	if err != nil {
+		return
+	}
+	address = address.WithZone("")
+	bits := ipv6Bits
+	switch {
+	case address.Is4():
+		bits = ipv4Bits
+	case address.Is4In6():
+		bits = address.BitLen() - 32 + ipv4Bits
+	}
+	if prefix, err := address.Prefix(bits); err == nil {
+		object[field] = prefix.Addr().String()
+	}
+}
@@ -0,0 +1,124 @@
+package openrtb_test
+
+import (
+	"github.com/LiveRamp/iabconsent"
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/openrtb"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scrub", func() {
+
+	const (
+		vendorID              = 5
+		testFullConsent       = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAAAA"
+		testNoConsent         = "CN-EdYAN-EdYAAKABBENAyCAAHAAAAAAAAhoAFAgAAAAAA"
+		testRestrictedConsent = "CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAACCAAgAFA"
+		testRequest           = `{
+			"id": "1",
+			"device": {"ifa": "abc", "dpidmd5": "def", "ip": "192.168.1.123", "ipv6": "2001:db8:85a3:1234:5678:8a2e:370:7334", "geo": {"lat": 51.507351, "lon": -0.127758}},
+			"user": {"id": "u", "buyeruid": "b", "yob": 1980, "geo": {"lat": 40.416775, "lon": -3.70379}}
+		}`
+	)
+
+	scrub := func(value string, vendorID int) string {
+		var consent iab_tcf.Consent
+		if value != "" {
+			var err error
+			consent, err = iab_tcf.NewConsent(value)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		data, err := openrtb.Scrub([]byte(testRequest), consent, vendorID)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	It("keeps everything with full consent", func() {
+		Expect(scrub(testFullConsent, vendorID)).To(MatchJSON(testRequest))
+	})
+
+	It("removes identifiers and precise geolocation without consent", func() {
+		Expect(scrub(testNoConsent, vendorID)).To(MatchJSON(`{
+			"id": "1",
+			"device": {"ip": "192.168.1.0", "ipv6": "2001:db8:85a3:1200::", "geo": {"lat": 51.51, "lon": -0.13}},
+			"user": {"yob": 1980, "geo": {"lat": 40.42, "lon": -3.7}}
+		}`))
+	})
+
+	It("removes identifiers for vendors without consent", func() {
+		Expect(scrub(testFullConsent, 6)).To(MatchJSON(`{
+			"id": "1",
+			"device": {"ip": "192.168.1.123", "ipv6": "2001:db8:85a3:1234:5678:8a2e:370:7334", "geo": {"lat": 51.507351, "lon": -0.127758}},
+			"user": {"yob": 1980, "geo": {"lat": 40.416775, "lon": -3.70379}}
+		}`))
+	})
+
+	It("removes identifiers for vendors restricted by the publisher", func() {
+		Expect(scrub(testRestrictedConsent, vendorID)).To(MatchJSON(`{
+			"id": "1",
+			"device": {"ip": "192.168.1.123", "ipv6": "2001:db8:85a3:1234:5678:8a2e:370:7334", "geo": {"lat": 51.507351, "lon": -0.127758}},
+			"user": {"yob": 1980, "geo": {"lat": 40.416775, "lon": -3.70379}}
+		}`))
+	})
+
+	DescribeTable("applies the restriction types of the publisher",
+		func(restrictionType iabconsent.RestrictionType, keepsIdentifiers bool) {
+			consent, err := iab_tcf.NewConsent(testRestrictedConsent)
+			Expect(err).NotTo(HaveOccurred())
+			parsedConsent := consent.(*iab_tcf.ConsentV2).ToParsedConsent()
+			parsedConsent.PubRestrictionEntries[0].RestrictionType = restrictionType
+			encoded, err := iab_tcf.EncodeV2(parsedConsent)
+			Expect(err).NotTo(HaveOccurred())
+			if keepsIdentifiers {
+				Expect(scrub(encoded, vendorID)).To(MatchJSON(testRequest))
+			} else {
+				Expect(scrub(encoded, vendorID)).To(MatchJSON(scrub(testRestrictedConsent, vendorID)))
+			}
+		},
+		Entry("requiring consent", iabconsent.RequireConsent, true),
+		Entry("requiring legitimate interest", iabconsent.RequireLegitimateInterest, false),
+		Entry("undefined", iabconsent.Undefined, true),
+	)
+
+	It("removes the extended identifiers without consent", func() {
+		request := `{"user": {"id": "u", "eids": [{"source": "a.com"}], "ext": {"eids": [{"source": "b.com"}], "consent": "c"}}}`
+		data, err := openrtb.Scrub([]byte(request), nil, vendorID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"user": {"ext": {"consent": "c"}}}`))
+	})
+
+	It("masks the IP addresses by their family", func() {
+		request := `{"device": {"ip": "::ffff:192.168.1.123", "ipv6": "192.168.1.123"}}`
+		data, err := openrtb.Scrub([]byte(request), nil, vendorID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"device": {"ip": "::ffff:192.168.1.0", "ipv6": "192.168.1.0"}}`))
+	})
+
+	It("treats a missing consent as no consent", func() {
+		Expect(scrub("", vendorID)).To(MatchJSON(scrub(testNoConsent, vendorID)))
+	})
+
+	It("removes precise geolocation with consents without special features", func() {
+		consent, err := iab_tcf.NewConsent(testFullConsent)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := openrtb.Scrub([]byte(testRequest), consentWithoutFeatures{consent}, vendorID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"id": "1",
+			"device": {"ifa": "abc", "dpidmd5": "def", "ip": "192.168.1.0", "ipv6": "2001:db8:85a3:1200::", "geo": {"lat": 51.51, "lon": -0.13}},
+			"user": {"id": "u", "buyeruid": "b", "yob": 1980, "geo": {"lat": 40.42, "lon": -3.7}}
+		}`))
+	})
+
+	It("returns an error with an invalid request", func() {
+		_, err := openrtb.Scrub([]byte("{"), nil, vendorID)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+// consentWithoutFeatures is a consent implemented outside the library, without the
+// optional SpecialFeatureConsent interface.
+type consentWithoutFeatures struct {
+	iab_tcf.Consent
+}
@@ -2,6 +2,7 @@ package iab_tcf
 
 import (
 	"slices"
+	"time"
 
 	"github.com/LiveRamp/iabconsent"
 	"github.com/hybridtheory/iab-tcf/cmp"
@@ -9,9 +10,13 @@ import (
 
 // ConsentV1 is an implementation of the Consent interface used to retrieve
 // consent information given a TCF 1.0 format.
+// It's immutable once created, so it's safe for concurrent use by multiple goroutines:
+// every method returning maps, slices or pointers returns a copy of them.
 type ConsentV1 struct {
 	*cmp.Consent
-	ParsedConsent *iabconsent.ParsedConsent
+	parsedConsent *iabconsent.ParsedConsent
+	// consentString is the string the consent was decoded from, if any.
+	consentString string
 }
 
 // NewConsentV1 returns a consent interface from the reader received, with
@@ -22,10 +27,57 @@ func NewConsentV1(reader *iabconsent.ConsentReader) (Consent, error) {
 		return nil, err
 	}
 	return &ConsentV1{
-		ParsedConsent: parsedConsent,
+		parsedConsent: parsedConsent,
 	}, nil
 }
 
+// ToParsedConsent returns a copy of the consent as an iabconsent parsed consent, e.g. to
+// encode it again.
+func (c *ConsentV1) ToParsedConsent() *iabconsent.ParsedConsent {
+	return copyParsedConsentV1(c.parsedConsent)
+}
+
+// Created returns when the consent string was created.
+func (c *ConsentV1) Created() time.Time {
+	return c.parsedConsent.Created
+}
+
+// LastUpdated returns when the consent string was last updated.
+func (c *ConsentV1) LastUpdated() time.Time {
+	return c.parsedConsent.LastUpdated
+}
+
+// CMPVersion returns the version of the CMP that last updated the consent string.
+func (c *ConsentV1) CMPVersion() int {
+	return c.parsedConsent.CMPVersion
+}
+
+// ConsentScreen returns the screen number of the CMP where the consent was given.
+func (c *ConsentV1) ConsentScreen() int {
+	return c.parsedConsent.ConsentScreen
+}
+
+// ConsentLanguage returns the two letters ISO 639-1 language code of the CMP, uppercase.
+func (c *ConsentV1) ConsentLanguage() string {
+	return c.parsedConsent.ConsentLanguage
+}
+
+// VendorListVersion returns the version of the Global Vendor List used by the CMP.
+func (c *ConsentV1) VendorListVersion() int {
+	return c.parsedConsent.VendorListVersion
+}
+
+// MaxVendorID returns the greatest vendor ID of the consent string.
+func (c *ConsentV1) MaxVendorID() int {
+	return c.parsedConsent.MaxVendorID
+}
+
+// ConsentString returns the consent string the consent was decoded from, or an empty
+// string if it was created from a reader or unmarshalled.
+func (c *ConsentV1) ConsentString() string {
+	return c.consentString
+}
+
 // Version returns the version of this consent string.
 func (c *ConsentV1) Version() int {
 	return int(iabconsent.V1)
@@ -33,7 +85,7 @@ func (c *ConsentV1) Version() int {
 
 // CMPID returns the CMP ID of this consent string.
 func (c *ConsentV1) CMPID() int {
-	return c.ParsedConsent.CMPID
+	return c.parsedConsent.CMPID
 }
 
 // IsCMPValid validates the consent string CMP ID agains the list of valid ones downloaded from IAB.
@@ -44,17 +96,19 @@ func (c *ConsentV1) IsCMPValid() bool {
 // HasConsentedPurpose returns always true because consent TFC 1.0 doesn't
 // come with this information.
 func (c *ConsentV1) HasConsentedPurpose(purposeID int) bool {
-	return c.ParsedConsent.PurposesAllowed[purposeID]
+	return c.parsedConsent.PurposesAllowed[purposeID]
 }
 
 // GetConsentPurposeBitstring returns a string of 1 & 0 each of them representing the consent
 // given for a specific purposeID.
 func (c *ConsentV1) GetConsentPurposeBitstring() string {
-	bitString := ""
-	for i := 1; i <= 24; i++ {
-		bitString += booleanFormatter[c.HasConsentedPurpose(i)]
-	}
-	return bitString
+	return FormatBits(24, c.HasConsentedPurpose)
+}
+
+// AppendConsentPurposeBits appends the GetConsentPurposeBitstring characters to dst
+// and returns the extended buffer.
+func (c *ConsentV1) AppendConsentPurposeBits(dst []byte) []byte {
+	return AppendBits(dst, 24, c.HasConsentedPurpose)
 }
 
 // HasConsentedLegitimateInterestForPurpose returns always true because consent TFC 1.0 doesn't
@@ -63,10 +117,16 @@ func (c *ConsentV1) HasConsentedLegitimateInterestForPurpose(purposeID int) bool
 	return true
 }
 
+// HasSpecialFeatureOptIn returns always false because consent TFC 1.0 doesn't
+// come with this information.
+func (c *ConsentV1) HasSpecialFeatureOptIn(featureID int) bool {
+	return false
+}
+
 // HasUserConsented returns true if the user has given consent to the vendorID passed
 // as parameter.
 func (c *ConsentV1) HasUserConsented(vendorID int) bool {
-	return c.ParsedConsent.VendorAllowed(vendorID)
+	return c.parsedConsent.VendorAllowed(vendorID)
 }
 
 // HasUserLegitimateInterest returns always true because consent TFC 1.0 doesn't
@@ -78,11 +138,31 @@ func (c *ConsentV1) HasUserLegitimateInterest(vendorID int) bool {
 // GetConsentBitstring returns a string of 1 & 0 each of them representing the consent
 // given for a specific vendorID (the first number is for the vendorID 1, and so on).
 func (c *ConsentV1) GetConsentBitstring() string {
-	bitString := ""
-	for i := 1; i <= c.ParsedConsent.MaxVendorID; i++ {
-		bitString += booleanFormatter[c.HasUserConsented(i)]
+	if c.parsedConsent.IsRangeEncoding {
+		return c.rangeVendors().format(c.parsedConsent.MaxVendorID)
 	}
-	return bitString
+	return FormatBits(c.parsedConsent.MaxVendorID, c.HasUserConsented)
+}
+
+// AppendConsentBits appends the GetConsentBitstring characters to dst and returns
+// the extended buffer.
+func (c *ConsentV1) AppendConsentBits(dst []byte) []byte {
+	if c.parsedConsent.IsRangeEncoding {
+		return c.rangeVendors().appendBits(dst, c.parsedConsent.MaxVendorID)
+	}
+	return AppendBits(dst, c.parsedConsent.MaxVendorID, c.HasUserConsented)
+}
+
+// rangeVendors returns the vendors allowed by the range entries as a bitset, taking into
+// account the default consent, so the bitstrings don't scan the entries for every vendor.
+func (c *ConsentV1) rangeVendors() bitset {
+	vendors := bitsetFromParsed(c.parsedConsent.MaxVendorID, nil, c.parsedConsent.RangeEntries)
+	if c.parsedConsent.DefaultConsent {
+		for i := range vendors {
+			vendors[i] = ^vendors[i]
+		}
+	}
+	return vendors
 }
 
 // GetInterestsBitstring returns an empty string always because consent TFC 1.0 doesn't
@@ -91,6 +171,12 @@ func (c *ConsentV1) GetInterestsBitstring() string {
 	return ""
 }
 
+// AppendInterestsBits returns dst as is because consent TFC 1.0 doesn't implement
+// user legitimate interests.
+func (c *ConsentV1) AppendInterestsBits(dst []byte) []byte {
+	return dst
+}
+
 // GetPublisherRestrictions returns an empty list because consent TFC 1.0 doesn't
 // implement user legitimate interests.
 func (c *ConsentV1) GetPublisherRestrictions() []*iabconsent.PubRestrictionEntry {
@@ -102,9 +188,11 @@ func (c *ConsentV1) GetPublisherRestrictions() []*iabconsent.PubRestrictionEntry
 func ParseV1(r *iabconsent.ConsentReader) (*iabconsent.ParsedConsent, error) {
 	var p = &iabconsent.ParsedConsent{}
 	p.Version = int(iabconsent.V1)
-	r.ReadString(12)
+	p.Created, _ = r.ReadTime()
+	p.LastUpdated, _ = r.ReadTime()
 	p.CMPID, _ = r.ReadInt(12)
-	r.ReadString(3)
+	p.CMPVersion, _ = r.ReadInt(12)
+	p.ConsentScreen, _ = r.ReadInt(6)
 	p.ConsentLanguage, _ = r.ReadString(2)
 	p.VendorListVersion, _ = r.ReadInt(12)
 	p.PurposesAllowed, _ = r.ReadBitField(24)
@@ -117,5 +205,5 @@ func ParseV1(r *iabconsent.ConsentReader) (*iabconsent.ParsedConsent, error) {
 	} else {
 		p.ConsentedVendors, _ = r.ReadBitField(uint(p.MaxVendorID))
 	}
-	return p, r.Err
+	return p, unexpectedEnd(r.Err)
 }
@@ -1,17 +1,64 @@
 package iab_tcf
 
 import (
+	"encoding/base64"
+	"errors"
+	"iter"
 	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/LiveRamp/iabconsent"
 	"github.com/hybridtheory/iab-tcf/cmp"
 )
 
 // ConsentV2 is an implementation of the Consent interface used to retrieve
-// consent information given a TCF 1.0 format.
+// consent information given a TCF 2.0 format. The purposes, special features and
+// vendors are kept as bitsets, with the vendor ranges already expanded, so every
+// lookup takes constant time.
+// It's immutable once created, so it's safe for concurrent use by multiple goroutines,
+// including the ones lazily decoded: every method returning maps, slices or pointers
+// returns a copy of them.
 type ConsentV2 struct {
 	cmp.Consent
-	ParsedConsent *iabconsent.V2ParsedConsent
+
+	// consentString is the string the consent was decoded from, if any.
+	consentString        string
+	created              time.Time
+	lastUpdated          time.Time
+	cmpID                int
+	cmpVersion           int
+	consentScreen        int
+	consentLanguage      [2]byte
+	vendorListVersion    int
+	tcfPolicyVersion     int
+	isServiceSpecific    bool
+	useNonStandardStacks bool
+	specialFeatures      uint32
+	purposesConsent      uint32
+	purposesLI           uint32
+	purposeOneTreatment  bool
+	publisherCC          [2]byte
+
+	// The vendor sections, restrictions and segments are decoded by decodeSections from
+	// the pending reader and segments, right away or on first access if lazily decoded.
+	decoded              atomic.Bool
+	mu                   sync.Mutex
+	pending              bitReader
+	pendingSegments      string
+	sectionsErr          error
+	maxConsentVendorID   int
+	isConsentRange       bool
+	consentedVendors     bitset
+	maxInterestsVendorID int
+	isInterestsRange     bool
+	interestsVendors     bitset
+	restrictions         []*iabconsent.PubRestrictionEntry
+	disclosedVendors     *iabconsent.OOBVendorList
+	allowedVendors       *iabconsent.OOBVendorList
+	publisherTC          *iabconsent.PublisherTCEntry
 }
 
 // NewConsentV2 returns a consent interface from the reader received, with
@@ -21,9 +68,191 @@ func NewConsentV2(reader *iabconsent.ConsentReader) (Consent, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &ConsentV2{
-		ParsedConsent: parsedConsent,
-	}, nil
+	return newConsentV2FromParsed(parsedConsent), nil
+}
+
+// DecodeConsentV2 decodes a TCF 2.0 version consent string without going through
+// the iabconsent reader, allocating just the bitsets of the vendors. The segments
+// following the core one are decoded on a best effort basis, ignoring them if invalid.
+// It returns ErrInvalidVersion if the consent string is not a TCF 2.0 one.
+func DecodeConsentV2(consent string) (*ConsentV2, error) {
+	decoded, err := DecodeConsent(consent)
+	if err != nil {
+		return nil, err
+	}
+	r := &bitReader{data: decoded}
+	if iabconsent.TCFVersion(r.readInt(6)) != iabconsent.V2 {
+		return nil, ErrInvalidVersion
+	}
+	return decodeConsentV2(r, consent, false)
+}
+
+// decodeConsentV2 decodes the consent string from the reader of its core segment, placed
+// right after the version. If lazy, only the header is decoded, leaving the rest for the
+// first access to them.
+func decodeConsentV2(r *bitReader, consent string, lazy bool) (*ConsentV2, error) {
+	c := &ConsentV2{consentString: consent}
+	if err := c.parseHeader(r); err != nil {
+		return nil, err
+	}
+	c.pending = *r
+	_, c.pendingSegments, _ = strings.Cut(consent, ".")
+	if !lazy {
+		c.decodeSections()
+		if c.sectionsErr != nil {
+			return nil, c.sectionsErr
+		}
+	}
+	return c, nil
+}
+
+// parseHeader reads the fields of the core segment before the vendor sections, right
+// after the version.
+func (c *ConsentV2) parseHeader(r *bitReader) error {
+	c.created = r.readTime()
+	c.lastUpdated = r.readTime()
+	c.cmpID = r.readInt(12)
+	c.cmpVersion = r.readInt(12)
+	c.consentScreen = r.readInt(6)
+	r.readLetters(c.consentLanguage[:])
+	c.vendorListVersion = r.readInt(12)
+	c.tcfPolicyVersion = r.readInt(6)
+	c.isServiceSpecific = r.readBool()
+	c.useNonStandardStacks = r.readBool()
+	c.specialFeatures = r.readFlags(12)
+	c.purposesConsent = r.readFlags(24)
+	c.purposesLI = r.readFlags(24)
+	c.purposeOneTreatment = r.readBool()
+	r.readLetters(c.publisherCC[:])
+	return r.err
+}
+
+// decodeSections decodes the vendor sections, the restrictions and the segments following
+// the core one the first time it's called. If the core segment is invalid the consent is
+// left without vendors nor restrictions and the error is kept to be returned by Err.
+func (c *ConsentV2) decodeSections() {
+	// A sync.Once would need a closure, allocating on every consent decoded.
+	if c.decoded.Load() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.decoded.Load() {
+		return
+	}
+	defer c.decoded.Store(true)
+	if c.sectionsErr = c.parseSections(&c.pending); c.sectionsErr != nil {
+		c.maxConsentVendorID, c.consentedVendors = 0, nil
+		c.maxInterestsVendorID, c.interestsVendors = 0, nil
+		c.restrictions = nil
+	}
+	if c.pendingSegments != "" {
+		// The core segment is enough to take decisions, so the rest are decoded
+		// on a best effort basis to keep accepting strings with unknown segments.
+		p := &iabconsent.V2ParsedConsent{}
+		ParseV2Segments(p, strings.Split(c.pendingSegments, "."))
+		c.disclosedVendors, c.allowedVendors, c.publisherTC = p.OOBDisclosedVendors, p.OOBAllowedVendors, p.PublisherTCEntry
+	}
+	c.pending, c.pendingSegments = bitReader{}, ""
+}
+
+// parseSections reads the vendor sections and the restrictions of the core segment.
+func (c *ConsentV2) parseSections(r *bitReader) error {
+	c.maxConsentVendorID, c.isConsentRange, c.consentedVendors = r.readVendors()
+	c.maxInterestsVendorID, c.isInterestsRange, c.interestsVendors = r.readVendors()
+	numRestrictions := r.readInt(12)
+	for i := 0; i < numRestrictions && r.err == nil; i++ {
+		restriction := &iabconsent.PubRestrictionEntry{
+			PurposeID:       r.readInt(6),
+			RestrictionType: iabconsent.RestrictionType(r.readInt(2)),
+		}
+		restriction.NumEntries = r.readInt(12)
+		for j := 0; j < restriction.NumEntries && r.err == nil; j++ {
+			start, end := r.readRangeEntry(maxVendorIDLimit)
+			restriction.RestrictionsRange = append(restriction.RestrictionsRange,
+				&iabconsent.RangeEntry{StartVendorID: start, EndVendorID: end})
+		}
+		c.restrictions = append(c.restrictions, restriction)
+	}
+	return r.err
+}
+
+// Err returns the error found decoding the vendor sections and restrictions, decoding
+// them first if the consent was lazily decoded. It's always nil for the consents that
+// weren't lazily decoded, as they return the error when created instead.
+func (c *ConsentV2) Err() error {
+	c.decodeSections()
+	return c.sectionsErr
+}
+
+// newConsentV2FromParsed returns the consent with a copy of the information of the parsed consent.
+func newConsentV2FromParsed(p *iabconsent.V2ParsedConsent) *ConsentV2 {
+	c := &ConsentV2{
+		created:              p.Created,
+		lastUpdated:          p.LastUpdated,
+		cmpID:                p.CMPID,
+		cmpVersion:           p.CMPVersion,
+		consentScreen:        p.ConsentScreen,
+		vendorListVersion:    p.VendorListVersion,
+		tcfPolicyVersion:     p.TCFPolicyVersion,
+		isServiceSpecific:    p.IsServiceSpecific,
+		useNonStandardStacks: p.UseNonStandardStacks,
+		specialFeatures:      flagsFromMap(p.SpecialFeaturesOptIn),
+		purposesConsent:      flagsFromMap(p.PurposesConsent),
+		purposesLI:           flagsFromMap(p.PurposesLITransparency),
+		purposeOneTreatment:  p.PurposeOneTreatment,
+		maxConsentVendorID:   p.MaxConsentVendorID,
+		isConsentRange:       p.IsConsentRangeEncoding,
+		consentedVendors:     bitsetFromParsed(p.MaxConsentVendorID, p.ConsentedVendors, p.ConsentedVendorsRange),
+		maxInterestsVendorID: p.MaxInterestsVendorID,
+		isInterestsRange:     p.IsInterestsRangeEncoding,
+		interestsVendors:     bitsetFromParsed(p.MaxInterestsVendorID, p.InterestsVendors, p.InterestsVendorsRange),
+		restrictions:         copyRestrictions(p.PubRestrictionEntries),
+		disclosedVendors:     copyVendorList(p.OOBDisclosedVendors),
+		allowedVendors:       copyVendorList(p.OOBAllowedVendors),
+		publisherTC:          copyPublisherTC(p.PublisherTCEntry),
+	}
+	copy(c.consentLanguage[:], p.ConsentLanguage)
+	copy(c.publisherCC[:], p.PublisherCC)
+	// Everything is already decoded, so there are no pending sections.
+	c.decoded.Store(true)
+	return c
+}
+
+// flagsFromMap returns the IDs set in the map as a mask where the ID i is the bit i-1.
+func flagsFromMap(values map[int]bool) uint32 {
+	var flags uint32
+	for id, value := range values {
+		if value && id >= 1 && id <= 32 {
+			flags |= 1 << (id - 1)
+		}
+	}
+	return flags
+}
+
+// hasFlag returns true if the ID is set in the mask.
+func hasFlag(flags uint32, id int) bool {
+	return id >= 1 && id <= 32 && flags&(1<<(id-1)) != 0
+}
+
+// bitsetFromParsed returns the vendors of a bit field or range entries as a bitset.
+func bitsetFromParsed(maxVendorID int, vendors map[int]bool, entries []*iabconsent.RangeEntry) bitset {
+	set := newBitset(maxVendorID)
+	for id, value := range vendors {
+		if value {
+			set.setRange(id, id)
+		}
+	}
+	for _, entry := range entries {
+		set.setRange(entry.StartVendorID, entry.EndVendorID)
+	}
+	return set
+}
+
+// ConsentString returns the consent string the consent was decoded from, or an empty
+// string if it was created from a reader or unmarshalled.
+func (c *ConsentV2) ConsentString() string {
+	return c.consentString
 }
 
 // Version returns the version of this consent string.
@@ -33,7 +262,7 @@ func (c *ConsentV2) Version() int {
 
 // CMPID returns the CMP ID of this consent string.
 func (c *ConsentV2) CMPID() int {
-	return c.ParsedConsent.CMPID
+	return c.cmpID
 }
 
 // IsCMPValid validates the consent string CMP ID agains the list of valid ones downloaded from IAB.
@@ -44,78 +273,264 @@ func (c *ConsentV2) IsCMPValid() bool {
 // HasConsentedPurpose returns the consent value for a Purpose established on the legal basis of consent.
 // The Purposes are numerically identified and published in the Global Vendor List.
 func (c *ConsentV2) HasConsentedPurpose(purposeID int) bool {
-	return c.ParsedConsent.PurposesConsent[purposeID]
+	return hasFlag(c.purposesConsent, purposeID)
 }
 
 // GetConsentPurposeBitstring returns a string of 1 & 0 each of them representing the consent
 // given for a specific purposeID.
 func (c *ConsentV2) GetConsentPurposeBitstring() string {
-	bitString := ""
-	for i := 1; i <= 24; i++ {
-		bitString += booleanFormatter[c.HasConsentedPurpose(i)]
-	}
-	return bitString
+	return FormatBits(24, c.HasConsentedPurpose)
+}
+
+// AppendConsentPurposeBits appends the GetConsentPurposeBitstring characters to dst
+// and returns the extended buffer.
+func (c *ConsentV2) AppendConsentPurposeBits(dst []byte) []byte {
+	return AppendBits(dst, 24, c.HasConsentedPurpose)
 }
 
 // HasConsentedLegitimateInterestForPurpose returns the Purpose’s transparency requirements
 // are met for each Purpose on the legal basis of legitimate interest and the user has not
 // exercised their “Right to Object” to that Purpose.
 func (c *ConsentV2) HasConsentedLegitimateInterestForPurpose(purposeID int) bool {
-	return c.ParsedConsent.PurposesLITransparency[purposeID]
+	return hasFlag(c.purposesLI, purposeID)
+}
+
+// HasSpecialFeatureOptIn returns true if the user opted in to the Special Feature passed
+// as parameter, e.g. 1 to use precise geolocation data.
+func (c *ConsentV2) HasSpecialFeatureOptIn(featureID int) bool {
+	return hasFlag(c.specialFeatures, featureID)
 }
 
 // HasUserConsented returns true if the user has given consent to the vendorID passed
 // as parameter.
 func (c *ConsentV2) HasUserConsented(vendorID int) bool {
-	if c.ParsedConsent.IsConsentRangeEncoding {
-		for _, re := range c.ParsedConsent.ConsentedVendorsRange {
-			if re.StartVendorID <= vendorID && vendorID <= re.EndVendorID {
-				return true
-			}
-		}
-		return false
-	}
-	return c.ParsedConsent.ConsentedVendors[vendorID]
+	c.decodeSections()
+	return c.consentedVendors.has(vendorID)
 }
 
 // HasUserLegitimateInterest returns true if the CMP has established transparency for a vendor's
 // legitimate interest disclosures. If a user exercises their “Right To Object” to a vendor’s
 // processing based on a legitimate interest, then it returns false.
 func (c *ConsentV2) HasUserLegitimateInterest(vendorID int) bool {
-	if c.ParsedConsent.IsInterestsRangeEncoding {
-		for _, re := range c.ParsedConsent.InterestsVendorsRange {
-			if re.StartVendorID <= vendorID && vendorID <= re.EndVendorID {
-				return true
-			}
-		}
-		return false
-	}
-	return c.ParsedConsent.InterestsVendors[vendorID]
+	c.decodeSections()
+	return c.interestsVendors.has(vendorID)
+}
+
+// ConsentedVendors returns an iterator over the vendor IDs the user has given consent to,
+// in ascending order, no matter if they were encoded as a bit field or as ranges.
+func (c *ConsentV2) ConsentedVendors() iter.Seq[int] {
+	c.decodeSections()
+	return c.consentedVendors.all()
+}
+
+// ConsentedVendorSet returns the set of vendor IDs the user has given consent to.
+func (c *ConsentV2) ConsentedVendorSet() VendorSet {
+	c.decodeSections()
+	return VendorSet{vendors: c.consentedVendors}
+}
+
+// LegitimateInterestVendors returns an iterator over the vendor IDs with legitimate interest
+// established, in ascending order, no matter if they were encoded as a bit field or as ranges.
+func (c *ConsentV2) LegitimateInterestVendors() iter.Seq[int] {
+	c.decodeSections()
+	return c.interestsVendors.all()
+}
+
+// LegitimateInterestVendorSet returns the set of vendor IDs with legitimate interest established.
+func (c *ConsentV2) LegitimateInterestVendorSet() VendorSet {
+	c.decodeSections()
+	return VendorSet{vendors: c.interestsVendors}
 }
 
 // GetConsentBitstring returns a string of 1 & 0 each of them representing the consent
 // given for a specific vendorID (the first number is for the vendorID 1, and so on).
 func (c *ConsentV2) GetConsentBitstring() string {
-	bitString := ""
-	for i := 1; i <= c.ParsedConsent.MaxConsentVendorID; i++ {
-		bitString += booleanFormatter[c.HasUserConsented(i)]
-	}
-	return bitString
+	c.decodeSections()
+	return c.consentedVendors.format(c.maxConsentVendorID)
+}
+
+// AppendConsentBits appends the GetConsentBitstring characters to dst and returns
+// the extended buffer.
+func (c *ConsentV2) AppendConsentBits(dst []byte) []byte {
+	c.decodeSections()
+	return c.consentedVendors.appendBits(dst, c.maxConsentVendorID)
 }
 
 // GetInterestsBitstring returns a string of 1 & 0 each of them representing the user's interest to
 // `Right To Object` for a specific vendorID (the first number is for the vendorID 1, and so on).
 func (c *ConsentV2) GetInterestsBitstring() string {
-	bitString := ""
-	for i := 1; i <= c.ParsedConsent.MaxInterestsVendorID; i++ {
-		bitString += booleanFormatter[c.HasUserLegitimateInterest(i)]
-	}
-	return bitString
+	c.decodeSections()
+	return c.interestsVendors.format(c.maxInterestsVendorID)
+}
+
+// AppendInterestsBits appends the GetInterestsBitstring characters to dst and returns
+// the extended buffer.
+func (c *ConsentV2) AppendInterestsBits(dst []byte) []byte {
+	c.decodeSections()
+	return c.interestsVendors.appendBits(dst, c.maxInterestsVendorID)
 }
 
 // GetPublisherRestrictions returns a list of restrictions per publisher, if it relates.
 func (c *ConsentV2) GetPublisherRestrictions() []*iabconsent.PubRestrictionEntry {
-	return c.ParsedConsent.PubRestrictionEntries
+	c.decodeSections()
+	return copyRestrictions(c.restrictions)
+}
+
+// ToParsedConsent returns a copy of the consent as an iabconsent parsed consent, e.g. to
+// encode it again. The vendors of range encoded sections are returned as the minimum number of
+// ranges, so they may be grouped differently than in the original string.
+func (c *ConsentV2) ToParsedConsent() *iabconsent.V2ParsedConsent {
+	c.decodeSections()
+	p := &iabconsent.V2ParsedConsent{
+		Version:                  int(iabconsent.V2),
+		Created:                  c.created,
+		LastUpdated:              c.lastUpdated,
+		CMPID:                    c.cmpID,
+		CMPVersion:               c.cmpVersion,
+		ConsentScreen:            c.consentScreen,
+		ConsentLanguage:          string(c.consentLanguage[:]),
+		VendorListVersion:        c.vendorListVersion,
+		TCFPolicyVersion:         c.tcfPolicyVersion,
+		IsServiceSpecific:        c.isServiceSpecific,
+		UseNonStandardStacks:     c.useNonStandardStacks,
+		SpecialFeaturesOptIn:     flagsToMap(c.specialFeatures, 12),
+		PurposesConsent:          flagsToMap(c.purposesConsent, 24),
+		PurposesLITransparency:   flagsToMap(c.purposesLI, 24),
+		PurposeOneTreatment:      c.purposeOneTreatment,
+		PublisherCC:              string(c.publisherCC[:]),
+		MaxConsentVendorID:       c.maxConsentVendorID,
+		IsConsentRangeEncoding:   c.isConsentRange,
+		MaxInterestsVendorID:     c.maxInterestsVendorID,
+		IsInterestsRangeEncoding: c.isInterestsRange,
+		NumPubRestrictions:       len(c.restrictions),
+		PubRestrictionEntries:    copyRestrictions(c.restrictions),
+		OOBDisclosedVendors:      copyVendorList(c.disclosedVendors),
+		OOBAllowedVendors:        copyVendorList(c.allowedVendors),
+		PublisherTCEntry:         copyPublisherTC(c.publisherTC),
+	}
+	if c.isConsentRange {
+		p.ConsentedVendorsRange = c.consentedVendors.ranges()
+		p.NumConsentEntries = len(p.ConsentedVendorsRange)
+	} else {
+		p.ConsentedVendors = c.consentedVendors.toMap(c.maxConsentVendorID)
+	}
+	if c.isInterestsRange {
+		p.InterestsVendorsRange = c.interestsVendors.ranges()
+		p.NumInterestsEntries = len(p.InterestsVendorsRange)
+	} else {
+		p.InterestsVendors = c.interestsVendors.toMap(c.maxInterestsVendorID)
+	}
+	return p
+}
+
+// Created returns when the consent string was created.
+func (c *ConsentV2) Created() time.Time {
+	return c.created
+}
+
+// LastUpdated returns when the consent string was last updated.
+func (c *ConsentV2) LastUpdated() time.Time {
+	return c.lastUpdated
+}
+
+// CMPVersion returns the version of the CMP that last updated the consent string.
+func (c *ConsentV2) CMPVersion() int {
+	return c.cmpVersion
+}
+
+// ConsentScreen returns the screen number of the CMP where the consent was given.
+func (c *ConsentV2) ConsentScreen() int {
+	return c.consentScreen
+}
+
+// ConsentLanguage returns the two letters ISO 639-1 language code of the CMP, uppercase.
+func (c *ConsentV2) ConsentLanguage() string {
+	return string(c.consentLanguage[:])
+}
+
+// VendorListVersion returns the version of the Global Vendor List used by the CMP.
+func (c *ConsentV2) VendorListVersion() int {
+	return c.vendorListVersion
+}
+
+// TCFPolicyVersion returns the version of the TCF policies the consent string follows.
+func (c *ConsentV2) TCFPolicyVersion() int {
+	return c.tcfPolicyVersion
+}
+
+// IsServiceSpecific returns true if the consent applies only to the service that set it.
+func (c *ConsentV2) IsServiceSpecific() bool {
+	return c.isServiceSpecific
+}
+
+// UseNonStandardStacks returns true if the CMP used non-IAB standard stacks.
+func (c *ConsentV2) UseNonStandardStacks() bool {
+	return c.useNonStandardStacks
+}
+
+// PurposeOneTreatment returns true if purpose 1 was not disclosed, e.g. because of the
+// publisher country laws.
+func (c *ConsentV2) PurposeOneTreatment() bool {
+	return c.purposeOneTreatment
+}
+
+// PublisherCC returns the two letters ISO 3166-1 country code of the publisher, uppercase.
+func (c *ConsentV2) PublisherCC() string {
+	return string(c.publisherCC[:])
+}
+
+// MaxConsentVendorID returns the greatest vendor ID of the vendor consents section.
+func (c *ConsentV2) MaxConsentVendorID() int {
+	c.decodeSections()
+	return c.maxConsentVendorID
+}
+
+// MaxInterestsVendorID returns the greatest vendor ID of the vendor legitimate interests section.
+func (c *ConsentV2) MaxInterestsVendorID() int {
+	c.decodeSections()
+	return c.maxInterestsVendorID
+}
+
+// IsConsentRangeEncoding returns whether the vendor consents section is encoded with ranges.
+func (c *ConsentV2) IsConsentRangeEncoding() bool {
+	c.decodeSections()
+	return c.isConsentRange
+}
+
+// IsInterestsRangeEncoding returns whether the vendor legitimate interests section is encoded
+// with ranges.
+func (c *ConsentV2) IsInterestsRangeEncoding() bool {
+	c.decodeSections()
+	return c.isInterestsRange
+}
+
+// DisclosedVendors returns a copy of the disclosed vendors segment, or nil if not present.
+func (c *ConsentV2) DisclosedVendors() *iabconsent.OOBVendorList {
+	c.decodeSections()
+	return copyVendorList(c.disclosedVendors)
+}
+
+// AllowedVendors returns a copy of the allowed vendors segment, or nil if not present.
+func (c *ConsentV2) AllowedVendors() *iabconsent.OOBVendorList {
+	c.decodeSections()
+	return copyVendorList(c.allowedVendors)
+}
+
+// PublisherTC returns a copy of the publisher purposes segment, or nil if not present.
+func (c *ConsentV2) PublisherTC() *iabconsent.PublisherTCEntry {
+	c.decodeSections()
+	return copyPublisherTC(c.publisherTC)
+}
+
+// flagsToMap returns the IDs of the mask from 1 to n as a map, as returned by the iabconsent reader.
+func flagsToMap(flags uint32, n int) map[int]bool {
+	values := map[int]bool{}
+	for id := 1; id <= n; id++ {
+		if hasFlag(flags, id) {
+			values[id] = true
+		}
+	}
+	return values
 }
 
 // ParseV2 uses a consent reader to extract information from a TCF 2.0 version
@@ -123,9 +538,11 @@ func (c *ConsentV2) GetPublisherRestrictions() []*iabconsent.PubRestrictionEntry
 func ParseV2(r *iabconsent.ConsentReader) (*iabconsent.V2ParsedConsent, error) {
 	var p = &iabconsent.V2ParsedConsent{}
 	p.Version = int(iabconsent.V2)
-	r.ReadString(12)
+	p.Created, _ = r.ReadTime()
+	p.LastUpdated, _ = r.ReadTime()
 	p.CMPID, _ = r.ReadInt(12)
-	r.ReadString(3)
+	p.CMPVersion, _ = r.ReadInt(12)
+	p.ConsentScreen, _ = r.ReadInt(6)
 	p.ConsentLanguage, _ = r.ReadString(2)
 	p.VendorListVersion, _ = r.ReadInt(12)
 	p.TCFPolicyVersion, _ = r.ReadInt(6)
@@ -154,5 +571,74 @@ func ParseV2(r *iabconsent.ConsentReader) (*iabconsent.V2ParsedConsent, error) {
 	}
 	p.NumPubRestrictions, _ = r.ReadInt(12)
 	p.PubRestrictionEntries, _ = r.ReadPubRestrictionEntries(uint(p.NumPubRestrictions))
-	return p, r.Err
+	if r.Err != nil {
+		return p, unexpectedEnd(r.Err)
+	}
+	return p, checkParsedRanges(p)
+}
+
+// checkParsedRanges returns ErrInvalidRange if any range entry of the parsed consent ends
+// before its start or past its maximum vendor ID, as DecodeConsentV2 does.
+func checkParsedRanges(p *iabconsent.V2ParsedConsent) error {
+	if err := checkRangeEntries(p.ConsentedVendorsRange, p.MaxConsentVendorID); err != nil {
+		return err
+	}
+	if err := checkRangeEntries(p.InterestsVendorsRange, p.MaxInterestsVendorID); err != nil {
+		return err
+	}
+	for _, restriction := range p.PubRestrictionEntries {
+		if err := checkRangeEntries(restriction.RestrictionsRange, maxVendorIDLimit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRangeEntries returns ErrInvalidRange if any of the entries ends before its start
+// or past maxVendorID.
+func checkRangeEntries(entries []*iabconsent.RangeEntry, maxVendorID int) error {
+	for _, entry := range entries {
+		if err := checkRange(entry.StartVendorID, entry.EndVendorID, maxVendorID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseV2Segments extracts the disclosed vendors, the allowed vendors and the publisher
+// purposes from the segments following the core one of a TCF 2.0 version consent string.
+func ParseV2Segments(p *iabconsent.V2ParsedConsent, segments []string) error {
+	for _, segment := range segments {
+		decoded, err := base64.RawURLEncoding.DecodeString(segment)
+		if err != nil {
+			return err
+		}
+		r := iabconsent.NewConsentReader(decoded)
+		segmentType, err := r.ReadSegmentType()
+		if err != nil {
+			return unexpectedEnd(err)
+		}
+		var vendors *iabconsent.OOBVendorList
+		switch segmentType {
+		case iabconsent.DisclosedVendors:
+			vendors, err = r.ReadVendors(segmentType)
+			p.OOBDisclosedVendors = vendors
+		case iabconsent.AllowedVendors:
+			vendors, err = r.ReadVendors(segmentType)
+			p.OOBAllowedVendors = vendors
+		case iabconsent.PublisherTC:
+			p.PublisherTCEntry, err = r.ReadPublisherTCEntry()
+		default:
+			return errors.New("Invalid consent segment type found")
+		}
+		if err != nil {
+			return unexpectedEnd(err)
+		}
+		if vendors != nil {
+			if err := checkRangeEntries(vendors.VendorEntries, vendors.MaxVendorID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
@@ -0,0 +1,112 @@
+package resolver
+
+import (
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/gpp"
+	"github.com/hybridtheory/iab-tcf/usprivacy"
+)
+
+// Regime is the privacy regulation that applies to a request.
+type Regime int
+
+const (
+	// RegimeNone means no privacy regulation signal applies.
+	RegimeNone Regime = iota
+	// RegimeGDPR means the European TCF applies.
+	RegimeGDPR
+	// RegimeCanada means the Canadian TCF applies.
+	RegimeCanada
+	// RegimeUS means any of the US privacy laws applies, either through a GPP US
+	// section or through the legacy US Privacy string.
+	RegimeUS
+)
+
+// String returns the name of the regime.
+func (r Regime) String() string {
+	switch r {
+	case RegimeGDPR:
+		return "gdpr"
+	case RegimeCanada:
+		return "canada"
+	case RegimeUS:
+		return "us"
+	}
+	return "none"
+}
+
+// Decision is the normalised result of resolving all the privacy signals of a request.
+type Decision struct {
+	// Regime is the privacy regulation that applies.
+	Regime Regime
+	// SectionIDs are the applicable GPP section IDs, if any.
+	SectionIDs []int
+	// TCF is the TCF EU consent, taken from the GPP string or from `gdpr_consent`.
+	TCF iab_tcf.Consent
+	// TCFCanada is the TCF Canada consent taken from the GPP string.
+	TCFCanada *gpp.TCFCanada
+	// USSection is the applicable US National or US state section taken from the GPP string.
+	USSection gpp.USSection
+	// USPrivacy is the US Privacy consent, taken from the GPP string or from `us_privacy`.
+	USPrivacy *usprivacy.Consent
+	// GPC is true if the Global Privacy Control is set, either by the `Sec-GPC` header or
+	// by the GPC sub-section of the applicable US section.
+	GPC bool
+	// Errors contains the errors found parsing each one of the signals. A signal that
+	// failed to parse is treated as missing.
+	Errors []error
+}
+
+// GDPRApplies returns true if the European TCF applies.
+func (d *Decision) GDPRApplies() bool {
+	return d.Regime == RegimeGDPR
+}
+
+// IsOptedOutOfSale returns true if the user has opted out of the sale of their personal
+// data through any of the US signals or the Global Privacy Control.
+func (d *Decision) IsOptedOutOfSale() bool {
+	if d.GPC {
+		return true
+	}
+	if d.USSection != nil && d.USSection.IsOptedOutOfSale() {
+		return true
+	}
+	return d.USPrivacy != nil && d.USPrivacy.IsOptedOutOfSale()
+}
+
+// IsOptedOutOfTargetedAdvertising returns true if the user has opted out of targeted
+// advertising through any of the US signals or the Global Privacy Control.
+func (d *Decision) IsOptedOutOfTargetedAdvertising() bool {
+	if d.GPC {
+		return true
+	}
+	return d.USSection != nil && (d.USSection.IsOptedOutOfTargetedAdvertising() || d.USSection.IsOptedOutOfSharing())
+}
+
+// AllowsVendor returns true if the vendor can process the user data for all the purposes
+// passed as parameter under the applicable regime. Under GDPR and TCF Canada it requires
+// a valid consent for the vendor and the purposes, and under the US laws it requires the
+// user not to have opted out of the sale of their data.
+func (d *Decision) AllowsVendor(vendorID int, purposeIDs ...int) bool {
+	switch d.Regime {
+	case RegimeGDPR:
+		return hasConsent(d.TCF, vendorID, purposeIDs)
+	case RegimeCanada:
+		return d.TCFCanada != nil && hasConsent(d.TCFCanada, vendorID, purposeIDs)
+	case RegimeUS:
+		return !d.IsOptedOutOfSale()
+	}
+	return true
+}
+
+// hasConsent returns true if the consent has been given to the vendor and all the purposes.
+func hasConsent(consent iab_tcf.Consent, vendorID int, purposeIDs []int) bool {
+	if consent == nil || !consent.HasUserConsented(vendorID) {
+		return false
+	}
+	for _, purposeID := range purposeIDs {
+		if !consent.HasConsentedPurpose(purposeID) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,182 @@
+package resolver
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	"github.com/hybridtheory/iab-tcf/gpp"
+	"github.com/hybridtheory/iab-tcf/usprivacy"
+)
+
+// usSectionIDs contains the GPP US state sections, most specific first, followed by
+// the US National one.
+var usSectionIDs = []int{
+	gpp.USCaliforniaSID,
+	gpp.USVirginiaSID,
+	gpp.USColoradoSID,
+	gpp.USUtahSID,
+	gpp.USConnecticutSID,
+	gpp.USNationalSID,
+}
+
+// Signals contains the raw privacy signals of a request, as they are received.
+type Signals struct {
+	// GDPR is the `gdpr` flag: "1" if it applies, "0" if it doesn't and empty if unknown.
+	GDPR string
+	// GDPRConsent is the `gdpr_consent` TC string.
+	GDPRConsent string
+	// USPrivacy is the `us_privacy` string.
+	USPrivacy string
+	// GPP is the `gpp` string.
+	GPP string
+	// GPPSID is the `gpp_sid` list of applicable section IDs separated by commas.
+	GPPSID string
+	// GPC is the value of the `Sec-GPC` header.
+	GPC string
+}
+
+// Resolve parses every one of the signals received and decides which regime applies.
+//
+// The applicable GPP sections take precedence: TCF EU v2 first, then TCF Canada, then
+// the US state and US National sections and finally the US Privacy section. When the
+// GPP string lacks the applicable section the legacy field is used instead. Without
+// applicable GPP sections, GDPR applies if `gdpr` is "1", or if it's unknown and there's
+// a `gdpr_consent`, and otherwise the US regime applies if the `us_privacy` string does.
+func Resolve(signals Signals) *Decision {
+	d := &Decision{
+		GPC: signals.GPC == "1",
+	}
+	var gppConsent *gpp.Consent
+	if signals.GPP != "" {
+		if consent, err := gpp.NewConsent(signals.GPP); err == nil {
+			gppConsent = consent
+		} else {
+			d.Errors = append(d.Errors, err)
+		}
+	}
+	if signals.GPPSID != "" {
+		if sectionIDs, err := ParseSectionIDs(signals.GPPSID); err == nil {
+			d.SectionIDs = sectionIDs
+		} else {
+			d.Errors = append(d.Errors, err)
+		}
+	}
+	if len(d.SectionIDs) > 0 {
+		d.resolveGPP(gppConsent, signals)
+	} else {
+		d.resolveLegacy(signals)
+	}
+	return d
+}
+
+// ParseSectionIDs parses a `gpp_sid` list of section IDs separated by commas, ignoring
+// the values lower than 1 used to signal that no section applies.
+func ParseSectionIDs(value string) ([]int, error) {
+	sectionIDs := []int{}
+	for _, item := range strings.Split(value, ",") {
+		sectionID, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		if sectionID > 0 {
+			sectionIDs = append(sectionIDs, sectionID)
+		}
+	}
+	return sectionIDs, nil
+}
+
+// resolveGPP decides the regime from the applicable GPP sections.
+func (d *Decision) resolveGPP(gppConsent *gpp.Consent, signals Signals) {
+	switch {
+	case d.isApplicable(gpp.TCFEUv2SID):
+		d.Regime = RegimeGDPR
+		if gppConsent != nil && gppConsent.HasSection(gpp.TCFEUv2SID) {
+			d.TCF = d.check(gppConsent.TCFEUv2())
+		} else {
+			d.parseGDPRConsent(signals.GDPRConsent)
+		}
+	case d.isApplicable(gpp.TCFCAv1SID):
+		d.Regime = RegimeCanada
+		if gppConsent != nil && gppConsent.HasSection(gpp.TCFCAv1SID) {
+			if consent, err := gppConsent.TCFCanada(); err == nil {
+				d.TCFCanada = consent
+			} else {
+				d.Errors = append(d.Errors, err)
+			}
+		}
+	default:
+		for _, sectionID := range usSectionIDs {
+			if d.isApplicable(sectionID) && gppConsent != nil && gppConsent.HasSection(sectionID) {
+				if section, err := gppConsent.USSection(sectionID); err == nil {
+					d.Regime = RegimeUS
+					d.USSection = section
+					d.GPC = d.GPC || section.HasGPC()
+					break
+				} else {
+					d.Errors = append(d.Errors, err)
+				}
+			}
+		}
+		if d.isApplicable(gpp.USPv1SID) {
+			d.Regime = RegimeUS
+			if gppConsent != nil && gppConsent.HasSection(gpp.USPv1SID) {
+				if consent, err := gppConsent.USPrivacy(); err == nil {
+					d.USPrivacy = consent
+				} else {
+					d.Errors = append(d.Errors, err)
+				}
+			} else {
+				d.parseUSPrivacy(signals.USPrivacy)
+			}
+		}
+		if d.Regime == RegimeNone && slices.ContainsFunc(usSectionIDs, d.isApplicable) {
+			d.Regime = RegimeUS
+		}
+	}
+}
+
+// resolveLegacy decides the regime from the legacy `gdpr`, `gdpr_consent` and `us_privacy` fields.
+func (d *Decision) resolveLegacy(signals Signals) {
+	d.parseUSPrivacy(signals.USPrivacy)
+	switch {
+	case signals.GDPR == "1" || (signals.GDPR == "" && signals.GDPRConsent != ""):
+		d.Regime = RegimeGDPR
+		d.parseGDPRConsent(signals.GDPRConsent)
+	case d.USPrivacy != nil && d.USPrivacy.IsApplicable():
+		d.Regime = RegimeUS
+	}
+}
+
+// isApplicable returns if the section ID is one of the applicable ones.
+func (d *Decision) isApplicable(sectionID int) bool {
+	return slices.Contains(d.SectionIDs, sectionID)
+}
+
+// parseGDPRConsent parses the TC string, if any.
+func (d *Decision) parseGDPRConsent(value string) {
+	if value != "" {
+		d.TCF = d.check(iab_tcf.NewConsent(value))
+	}
+}
+
+// parseUSPrivacy parses the US Privacy string, if any.
+func (d *Decision) parseUSPrivacy(value string) {
+	if value != "" {
+		if consent, err := usprivacy.NewConsent(value); err == nil {
+			d.USPrivacy = consent
+		} else {
+			d.Errors = append(d.Errors, err)
+		}
+	}
+}
+
+// check returns the consent if there was no error, keeping track of the error otherwise.
+func (d *Decision) check(consent iab_tcf.Consent, err error) iab_tcf.Consent {
+	if err != nil {
+		d.Errors = append(d.Errors, err)
+		return nil
+	}
+	return consent
+}
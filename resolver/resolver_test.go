@@ -0,0 +1,137 @@
+package resolver_test
+
+import (
+	"github.com/hybridtheory/iab-tcf/gpp"
+	"github.com/hybridtheory/iab-tcf/resolver"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resolver", func() {
+
+	const (
+		testTCFConsent   = "COxR03kOxR1CqBcABCENAgCMAP_AAH_AAAqIF3EXySoGY2thI2YVFxBEIYwfJxyigMgChgQIsSwNQIeFLBoGLiAAHBGYJAQAGBAEEACBAQIkHGBMCQAAgAgBiRCMQEGMCzNIBIBAggEbY0FACCVmHkHSmZCY7064O__QLuIJEFQMAkSBAIACLECIQwAQDiAAAYAlAAABAhIaAAgIWBQEeAAAACAwAAgAAABBAAACAAQAAICIAAABAAAgAiAQAAAAGgIQAACBABACRIAAAEANCAAgiCEAQg4EAo4AAA"
+		testGPPTCFUSP    = "DBACNY~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN"
+		testGPPUSNatGPC  = "DBABLA~BVVqAAEABCA.YA"
+		testGPPUSStates  = "DBABrGA~BVVqAAEABCA~BVoYYZoI~BVoYYYI~BVoYYQg~BVaGGGCA~BVoYYYQg"
+		testGPPTCFCanada = "DBABD~BO5rKAAO5rKAAAyACDENAwCQAYAAAGAAAAAVACgAEAAgACgAGBAAgoAMAAwAEA"
+	)
+
+	var decision *resolver.Decision
+
+	Context("without GPP", func() {
+		It("applies GDPR with the TC string", func() {
+			decision = resolver.Resolve(resolver.Signals{GDPR: "1", GDPRConsent: testTCFConsent})
+			Expect(decision.Regime).To(Equal(resolver.RegimeGDPR))
+			Expect(decision.GDPRApplies()).To(BeTrue())
+			Expect(decision.TCF.CMPID()).To(Equal(92))
+			Expect(decision.AllowsVendor(2, 2, 4)).To(BeTrue())
+			Expect(decision.AllowsVendor(2, 11)).To(BeFalse())
+			Expect(decision.AllowsVendor(1)).To(BeFalse())
+			Expect(decision.Errors).To(BeEmpty())
+		})
+
+		It("applies GDPR when the flag is unknown but there's a TC string", func() {
+			decision = resolver.Resolve(resolver.Signals{GDPRConsent: testTCFConsent})
+			Expect(decision.Regime).To(Equal(resolver.RegimeGDPR))
+		})
+
+		It("denies every vendor under GDPR without a valid TC string", func() {
+			decision = resolver.Resolve(resolver.Signals{GDPR: "1", GDPRConsent: "invalid"})
+			Expect(decision.Regime).To(Equal(resolver.RegimeGDPR))
+			Expect(decision.TCF).To(BeNil())
+			Expect(decision.AllowsVendor(2)).To(BeFalse())
+			Expect(decision.Errors).To(HaveLen(1))
+		})
+
+		It("applies the US regime with the US Privacy string", func() {
+			decision = resolver.Resolve(resolver.Signals{GDPR: "0", GDPRConsent: testTCFConsent, USPrivacy: "1YYN"})
+			Expect(decision.Regime).To(Equal(resolver.RegimeUS))
+			Expect(decision.TCF).To(BeNil())
+			Expect(decision.IsOptedOutOfSale()).To(BeTrue())
+			Expect(decision.AllowsVendor(2)).To(BeFalse())
+		})
+
+		It("doesn't apply any regime for not applicable US Privacy strings", func() {
+			decision = resolver.Resolve(resolver.Signals{USPrivacy: "1---"})
+			Expect(decision.Regime).To(Equal(resolver.RegimeNone))
+			Expect(decision.AllowsVendor(2)).To(BeTrue())
+		})
+
+		It("opts out of sale with GPC", func() {
+			decision = resolver.Resolve(resolver.Signals{GPC: "1"})
+			Expect(decision.Regime).To(Equal(resolver.RegimeNone))
+			Expect(decision.IsOptedOutOfSale()).To(BeTrue())
+			Expect(decision.IsOptedOutOfTargetedAdvertising()).To(BeTrue())
+		})
+	})
+
+	Context("with GPP", func() {
+		It("prefers the TCF EU v2 section", func() {
+			decision = resolver.Resolve(resolver.Signals{GPP: testGPPTCFUSP, GPPSID: "2,6", GDPRConsent: testTCFConsent})
+			Expect(decision.Regime).To(Equal(resolver.RegimeGDPR))
+			Expect(decision.SectionIDs).To(Equal([]int{2, 6}))
+			Expect(decision.TCF.CMPID()).To(Equal(31))
+		})
+
+		It("falls back to the TC string when the section is missing", func() {
+			decision = resolver.Resolve(resolver.Signals{GPP: testGPPUSNatGPC, GPPSID: "2", GDPRConsent: testTCFConsent})
+			Expect(decision.Regime).To(Equal(resolver.RegimeGDPR))
+			Expect(decision.TCF.CMPID()).To(Equal(92))
+		})
+
+		It("applies the US Privacy section", func() {
+			decision = resolver.Resolve(resolver.Signals{GPP: testGPPTCFUSP, GPPSID: "6", USPrivacy: "1YYN"})
+			Expect(decision.Regime).To(Equal(resolver.RegimeUS))
+			Expect(decision.USPrivacy.String()).To(Equal("1YNN"))
+			Expect(decision.IsOptedOutOfSale()).To(BeFalse())
+		})
+
+		It("applies the US National section with its GPC sub-section", func() {
+			decision = resolver.Resolve(resolver.Signals{GPP: testGPPUSNatGPC, GPPSID: "7"})
+			Expect(decision.Regime).To(Equal(resolver.RegimeUS))
+			Expect(decision.USSection.SectionID()).To(Equal(gpp.USNationalSID))
+			Expect(decision.GPC).To(BeTrue())
+			Expect(decision.IsOptedOutOfSale()).To(BeTrue())
+		})
+
+		It("prefers the state sections over the US National one", func() {
+			decision = resolver.Resolve(resolver.Signals{GPP: testGPPUSStates, GPPSID: "7,8"})
+			Expect(decision.USSection.SectionID()).To(Equal(gpp.USCaliforniaSID))
+			Expect(decision.AllowsVendor(2)).To(BeTrue())
+		})
+
+		It("applies the TCF Canada section", func() {
+			decision = resolver.Resolve(resolver.Signals{GPP: testGPPTCFCanada, GPPSID: "5"})
+			Expect(decision.Regime).To(Equal(resolver.RegimeCanada))
+			Expect(decision.AllowsVendor(2, 1)).To(BeTrue())
+			Expect(decision.AllowsVendor(3, 5)).To(BeFalse())
+		})
+
+		It("uses the legacy fields when no section applies", func() {
+			decision = resolver.Resolve(resolver.Signals{GPP: testGPPTCFUSP, GPPSID: "-1", GDPR: "1", GDPRConsent: testTCFConsent})
+			Expect(decision.Regime).To(Equal(resolver.RegimeGDPR))
+			Expect(decision.TCF.CMPID()).To(Equal(92))
+		})
+
+		It("keeps track of the errors", func() {
+			decision = resolver.Resolve(resolver.Signals{GPP: "invalid", GPPSID: "a"})
+			Expect(decision.Regime).To(Equal(resolver.RegimeNone))
+			Expect(decision.Errors).To(HaveLen(2))
+		})
+	})
+
+	DescribeTable("section IDs",
+		func(value string, expected []int) {
+			Expect(resolver.ParseSectionIDs(value)).To(Equal(expected))
+		},
+		Entry("a single one", "2", []int{2}),
+		Entry("several ones", "2, 6,7", []int{2, 6, 7}),
+		Entry("not applicable", "-1", []int{}),
+	)
+
+	It("names the regimes", func() {
+		Expect(resolver.RegimeGDPR.String()).To(Equal("gdpr"))
+		Expect(resolver.RegimeNone.String()).To(Equal("none"))
+	})
+})
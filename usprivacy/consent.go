@@ -0,0 +1,119 @@
+package usprivacy
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	// Version is the only version of the US Privacy string format published by IAB.
+	Version = 1
+	// Length is the number of characters of a US Privacy string.
+	Length = 4
+)
+
+var (
+	// ErrInvalidLength is returned when the string doesn't have exactly 4 characters.
+	ErrInvalidLength = errors.New("Invalid US Privacy string length")
+	// ErrInvalidVersion is returned when the version character is not a supported one.
+	ErrInvalidVersion = errors.New("Invalid US Privacy string version")
+	// ErrInvalidFlag is returned when any of the flags is not one of `Y`, `N` or `-`.
+	ErrInvalidFlag = errors.New("Invalid US Privacy string flag")
+)
+
+// Flag is the value of each one of the fields of a US Privacy string.
+type Flag byte
+
+const (
+	// Yes means the field applies and its answer is affirmative.
+	Yes Flag = 'Y'
+	// No means the field applies and its answer is negative.
+	No Flag = 'N'
+	// NotApplicable means the field doesn't apply to this user.
+	NotApplicable Flag = '-'
+)
+
+// IsValid returns if the flag is one of the values allowed by the specification.
+func (f Flag) IsValid() bool {
+	return f == Yes || f == No || f == NotApplicable
+}
+
+// Consent contains the information of a US Privacy (CCPA) string,
+// e.g. `1YNN`.
+type Consent struct {
+	// Version of the specification used to encode the string.
+	Version int
+	// Notice tells if explicit notice and opportunity to opt out has been provided.
+	Notice Flag
+	// OptOutSale tells if the user has opted out of the sale of their personal data.
+	OptOutSale Flag
+	// LSPACovered tells if the publisher is a signatory to the IAB Limited Service
+	// Provider Agreement and the transaction is covered by it.
+	LSPACovered Flag
+}
+
+// NewConsent parses a US Privacy string returning a Consent instance with all
+// the information available. It returns an error if the string is not valid.
+func NewConsent(consent string) (*Consent, error) {
+	if len(consent) != Length {
+		return nil, ErrInvalidLength
+	}
+	consent = strings.ToUpper(consent)
+	c := &Consent{
+		Version:     int(consent[0] - '0'),
+		Notice:      Flag(consent[1]),
+		OptOutSale:  Flag(consent[2]),
+		LSPACovered: Flag(consent[3]),
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate checks that all the fields contain values allowed by the specification.
+func (c *Consent) Validate() error {
+	if c.Version != Version {
+		return ErrInvalidVersion
+	}
+	for _, flag := range []Flag{c.Notice, c.OptOutSale, c.LSPACovered} {
+		if !flag.IsValid() {
+			return ErrInvalidFlag
+		}
+	}
+	return nil
+}
+
+// IsApplicable returns false if none of the fields apply to this user, i.e. `1---`.
+func (c *Consent) IsApplicable() bool {
+	return c.Notice != NotApplicable || c.OptOutSale != NotApplicable || c.LSPACovered != NotApplicable
+}
+
+// HasNotice returns true if the user was given notice and the opportunity to opt out.
+func (c *Consent) HasNotice() bool {
+	return c.Notice == Yes
+}
+
+// IsOptedOutOfSale returns true if the user has opted out of the sale of their personal data.
+func (c *Consent) IsOptedOutOfSale() bool {
+	return c.OptOutSale == Yes
+}
+
+// IsLSPACovered returns true if the transaction is covered by the IAB Limited Service
+// Provider Agreement.
+func (c *Consent) IsLSPACovered() bool {
+	return c.LSPACovered == Yes
+}
+
+// String encodes the consent back to its US Privacy string representation.
+func (c *Consent) String() string {
+	return string([]byte{byte('0' + c.Version), byte(c.Notice), byte(c.OptOutSale), byte(c.LSPACovered)})
+}
+
+// Encode validates the consent and returns its US Privacy string representation.
+func (c *Consent) Encode() (string, error) {
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+	return c.String(), nil
+}
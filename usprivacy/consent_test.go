@@ -0,0 +1,74 @@
+package usprivacy_test
+
+import (
+	"github.com/hybridtheory/iab-tcf/usprivacy"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Consent", func() {
+
+	var (
+		consent *usprivacy.Consent
+		err     error
+	)
+
+	Context("with a valid string", func() {
+		BeforeEach(func() {
+			consent, err = usprivacy.NewConsent("1YYN")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("parses every field", func() {
+			Expect(consent.Version).To(Equal(1))
+			Expect(consent.Notice).To(Equal(usprivacy.Yes))
+			Expect(consent.OptOutSale).To(Equal(usprivacy.Yes))
+			Expect(consent.LSPACovered).To(Equal(usprivacy.No))
+		})
+
+		It("returns the helpers values", func() {
+			Expect(consent.IsApplicable()).To(BeTrue())
+			Expect(consent.HasNotice()).To(BeTrue())
+			Expect(consent.IsOptedOutOfSale()).To(BeTrue())
+			Expect(consent.IsLSPACovered()).To(BeFalse())
+		})
+
+		It("encodes it back", func() {
+			Expect(consent.String()).To(Equal("1YYN"))
+			Expect(consent.Encode()).To(Equal("1YYN"))
+		})
+	})
+
+	It("is not applicable if every flag is a dash", func() {
+		consent, err = usprivacy.NewConsent("1---")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.IsApplicable()).To(BeFalse())
+		Expect(consent.IsOptedOutOfSale()).To(BeFalse())
+	})
+
+	It("accepts lowercase flags", func() {
+		consent, err = usprivacy.NewConsent("1ynn")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(consent.String()).To(Equal("1YNN"))
+	})
+
+	DescribeTable("invalid strings",
+		func(value string, expected error) {
+			consent, err = usprivacy.NewConsent(value)
+			Expect(consent).To(BeNil())
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("empty", "", usprivacy.ErrInvalidLength),
+		Entry("too short", "1YN", usprivacy.ErrInvalidLength),
+		Entry("too long", "1YNNN", usprivacy.ErrInvalidLength),
+		Entry("wrong version", "2YNN", usprivacy.ErrInvalidVersion),
+		Entry("non numeric version", "YYNN", usprivacy.ErrInvalidVersion),
+		Entry("wrong flag", "1YXN", usprivacy.ErrInvalidFlag),
+	)
+
+	It("doesn't encode invalid values", func() {
+		consent = &usprivacy.Consent{Version: 1, Notice: usprivacy.Yes, OptOutSale: 'X', LSPACovered: usprivacy.No}
+		_, err = consent.Encode()
+		Expect(err).To(MatchError(usprivacy.ErrInvalidFlag))
+	})
+})
@@ -0,0 +1,107 @@
+package iab_tcf
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+
+	"github.com/LiveRamp/iabconsent"
+)
+
+// VendorSet is an immutable set of vendor IDs, e.g. the vendors with consent, supporting
+// set algebra without probing every vendor ID.
+type VendorSet struct {
+	vendors bitset
+}
+
+// NewVendorSet returns the set of the vendor IDs received, ignoring the ones lower than 1.
+func NewVendorSet(vendorIDs ...int) VendorSet {
+	set := bitset{}
+	for _, vendorID := range vendorIDs {
+		set.setRange(vendorID, vendorID)
+	}
+	return VendorSet{vendors: set}
+}
+
+// NewVendorSetFromRanges returns the set of the vendor IDs of the range entries, e.g. the
+// ones of a range encoded vendor section, without going through every vendor ID of them.
+// The entries ending before their start are ignored.
+func NewVendorSetFromRanges(entries []*iabconsent.RangeEntry) VendorSet {
+	set := bitset{}
+	for _, entry := range entries {
+		set.setRange(entry.StartVendorID, entry.EndVendorID)
+	}
+	return VendorSet{vendors: set}
+}
+
+// Contains returns true if the vendor ID is in the set.
+func (s VendorSet) Contains(vendorID int) bool {
+	return s.vendors.has(vendorID)
+}
+
+// Len returns the number of vendor IDs in the set.
+func (s VendorSet) Len() int {
+	count := 0
+	for _, word := range s.vendors {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// All returns an iterator over the vendor IDs of the set, in ascending order.
+func (s VendorSet) All() iter.Seq[int] {
+	return s.vendors.all()
+}
+
+// IDs returns the vendor IDs of the set, sorted.
+func (s VendorSet) IDs() []int {
+	return slices.Collect(s.All())
+}
+
+// Ranges returns the vendor IDs of the set grouped in the minimum number of range
+// entries, sorted, e.g. to encode them as a range encoded vendor section.
+func (s VendorSet) Ranges() []*iabconsent.RangeEntry {
+	return s.vendors.ranges()
+}
+
+// Intersect returns the set of the vendor IDs in both sets.
+func (s VendorSet) Intersect(other VendorSet) VendorSet {
+	result := make(bitset, min(len(s.vendors), len(other.vendors)))
+	for i := range result {
+		result[i] = s.vendors[i] & other.vendors[i]
+	}
+	return VendorSet{vendors: result}
+}
+
+// Union returns the set of the vendor IDs in any of the sets.
+func (s VendorSet) Union(other VendorSet) VendorSet {
+	result := make(bitset, max(len(s.vendors), len(other.vendors)))
+	copy(result, s.vendors)
+	for i, word := range other.vendors {
+		result[i] |= word
+	}
+	return VendorSet{vendors: result}
+}
+
+// Difference returns the set of the vendor IDs in this set but not in the other one.
+func (s VendorSet) Difference(other VendorSet) VendorSet {
+	result := slices.Clone(s.vendors)
+	for i := 0; i < len(result) && i < len(other.vendors); i++ {
+		result[i] &^= other.vendors[i]
+	}
+	return VendorSet{vendors: result}
+}
+
+// all returns an iterator over the IDs of the set, skipping the empty words.
+func (b bitset) all() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, word := range b {
+			for word != 0 {
+				if !yield(i*64 + bits.TrailingZeros64(word) + 1) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
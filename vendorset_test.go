@@ -0,0 +1,107 @@
+package iab_tcf_test
+
+import (
+	"slices"
+
+	"github.com/LiveRamp/iabconsent"
+
+	iab_tcf "github.com/hybridtheory/iab-tcf"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VendorSet", func() {
+
+	It("contains the vendor IDs received", func() {
+		set := iab_tcf.NewVendorSet(130, 2, 64, 65, 2, 0, -1)
+		Expect(set.IDs()).To(Equal([]int{2, 64, 65, 130}))
+		Expect(set.Len()).To(Equal(4))
+		Expect(set.Contains(64)).To(BeTrue())
+		Expect(set.Contains(3)).To(BeFalse())
+		Expect(set.Contains(0)).To(BeFalse())
+		Expect(set.Contains(1000)).To(BeFalse())
+	})
+
+	It("contains the vendor IDs of the range entries", func() {
+		set := iab_tcf.NewVendorSetFromRanges([]*iabconsent.RangeEntry{
+			{StartVendorID: 60, EndVendorID: 130},
+			{StartVendorID: 2, EndVendorID: 2},
+			{StartVendorID: 9, EndVendorID: 8},
+		})
+		Expect(set.Len()).To(Equal(72))
+		Expect(set.Contains(2)).To(BeTrue())
+		Expect(set.Contains(59)).To(BeFalse())
+		Expect(set.Contains(60)).To(BeTrue())
+		Expect(set.Contains(130)).To(BeTrue())
+		Expect(set.Contains(131)).To(BeFalse())
+		Expect(set.Contains(8)).To(BeFalse())
+	})
+
+	It("groups the vendor IDs in ranges", func() {
+		set := iab_tcf.NewVendorSet(1, 2, 3, 5, 63, 64, 65, 200)
+		Expect(set.Ranges()).To(Equal([]*iabconsent.RangeEntry{
+			{StartVendorID: 1, EndVendorID: 3},
+			{StartVendorID: 5, EndVendorID: 5},
+			{StartVendorID: 63, EndVendorID: 65},
+			{StartVendorID: 200, EndVendorID: 200},
+		}))
+		Expect(iab_tcf.VendorSet{}.Ranges()).To(BeEmpty())
+	})
+
+	It("is empty by default", func() {
+		Expect(iab_tcf.VendorSet{}.Len()).To(Equal(0))
+		Expect(iab_tcf.VendorSet{}.IDs()).To(BeEmpty())
+	})
+
+	It("supports set algebra", func() {
+		a := iab_tcf.NewVendorSet(1, 5, 70, 200)
+		b := iab_tcf.NewVendorSet(5, 70, 71)
+		Expect(a.Intersect(b).IDs()).To(Equal([]int{5, 70}))
+		Expect(a.Union(b).IDs()).To(Equal([]int{1, 5, 70, 71, 200}))
+		Expect(a.Difference(b).IDs()).To(Equal([]int{1, 200}))
+		Expect(b.Difference(a).IDs()).To(Equal([]int{71}))
+		Expect(a.IDs()).To(Equal([]int{1, 5, 70, 200}))
+	})
+
+	It("stops iterating when requested", func() {
+		ids := []int{}
+		for id := range iab_tcf.NewVendorSet(1, 2, 3).All() {
+			ids = append(ids, id)
+			if id == 2 {
+				break
+			}
+		}
+		Expect(ids).To(Equal([]int{1, 2}))
+	})
+
+	DescribeTable("enumerates the vendors of a consent",
+		func(value string) {
+			consent, err := iab_tcf.NewConsent(value)
+			Expect(err).NotTo(HaveOccurred())
+			c := consent.(*iab_tcf.ConsentV2)
+			expectedConsents, expectedInterests := []int{}, []int{}
+			for vendorID := 1; vendorID <= 1000; vendorID++ {
+				if c.HasUserConsented(vendorID) {
+					expectedConsents = append(expectedConsents, vendorID)
+				}
+				if c.HasUserLegitimateInterest(vendorID) {
+					expectedInterests = append(expectedInterests, vendorID)
+				}
+			}
+			Expect(expectedConsents).NotTo(BeEmpty())
+			Expect(append([]int{}, slices.Collect(c.ConsentedVendors())...)).To(Equal(expectedConsents))
+			Expect(c.ConsentedVendorSet().IDs()).To(Equal(expectedConsents))
+			Expect(append([]int{}, slices.Collect(c.LegitimateInterestVendors())...)).To(Equal(expectedInterests))
+			Expect(c.LegitimateInterestVendorSet().Len()).To(Equal(len(expectedInterests)))
+		},
+		Entry("with a bit field", "COxR03kOxR1CqBcABCENAgCMAP_AAH_AAAqIF3EXySoGY2thI2YVFxBEIYwfJxyigMgChgQIsSwNQIeFLBoGLiAAHBGYJAQAGBAEEACBAQIkHGBMCQAAgAgBiRCMQEGMCzNIBIBAggEbY0FACCVmHkHSmZCY7064O__QLuIJEFQMAkSBAIACLECIQwAQDiAAAYAlAAABAhIaAAgIWBQEeAAAACAwAAgAAABBAAACAAQAAICIAAABAAAgAiAQAAAAGgIQAACBABACRIAAAEANCAAgiCEAQg4EAo4AAA"),
+		Entry("with ranges", "COytyllOytyllCrAAAENAiCMAFVAACqAAAAAF3QAgAFABkAAoioAAA"),
+	)
+
+	It("intersects the vendors with consent with a partner list", func() {
+		consent, err := iab_tcf.NewConsent("CN-EdYAN-EdYAAKABBENAyCIAPAAAAAAAAhoAFAgAAAAAA")
+		Expect(err).NotTo(HaveOccurred())
+		partners := iab_tcf.NewVendorSet(3, 5, 8)
+		Expect(consent.(*iab_tcf.ConsentV2).ConsentedVendorSet().Intersect(partners).IDs()).To(Equal([]int{5}))
+	})
+})